@@ -0,0 +1,75 @@
+package fn
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Batcher coalesces individual Load calls made within a short window into a single call to a
+// batch fetch function, in the style of a DataLoader. It is safe for concurrent use.
+type Batcher[K comparable, V any] struct {
+	wait  time.Duration
+	fetch func([]K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending *pendingBatch[K, V]
+}
+
+type pendingBatch[K comparable, V any] struct {
+	keys  []K
+	seen  map[K]struct{}
+	loads int
+	done  chan struct{}
+	vals  map[K]V
+	err   error
+}
+
+// NewBatcher returns a Batcher that waits up to wait after the first Load of a batch before
+// dispatching every key requested so far to fetch
+func NewBatcher[K comparable, V any](wait time.Duration, fetch func([]K) (map[K]V, error)) *Batcher[K, V] {
+	return &Batcher[K, V]{wait: wait, fetch: fetch}
+}
+
+// Load requests the value for k and blocks until the batch containing it has been fetched.
+// It returns the fetch error, or an error if the fetch result has no entry for k.
+func (b *Batcher[K, V]) Load(k K) (V, error) {
+	b.mu.Lock()
+	batch := b.pending
+	if batch == nil {
+		batch = &pendingBatch[K, V]{seen: make(map[K]struct{}), done: make(chan struct{})}
+		b.pending = batch
+		time.AfterFunc(b.wait, func() { b.dispatch(batch) })
+	}
+	batch.loads++
+	if _, ok := batch.seen[k]; !ok {
+		batch.seen[k] = struct{}{}
+		batch.keys = append(batch.keys, k)
+	}
+	b.mu.Unlock()
+
+	<-batch.done
+	if batch.err != nil {
+		var zero V
+		return zero, batch.err
+	}
+	v, ok := batch.vals[k]
+	if !ok {
+		return v, fmt.Errorf("fn: batch fetch returned no value for key %v", k)
+	}
+	return v, nil
+}
+
+func (b *Batcher[K, V]) dispatch(batch *pendingBatch[K, V]) {
+	b.mu.Lock()
+	if b.pending != batch {
+		// already dispatched
+		b.mu.Unlock()
+		return
+	}
+	b.pending = nil
+	b.mu.Unlock()
+
+	batch.vals, batch.err = b.fetch(batch.keys)
+	close(batch.done)
+}
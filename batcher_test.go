@@ -0,0 +1,97 @@
+package fn
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForPending blocks until n Load calls have joined the pending batch and returns it
+func waitForPending[K comparable, V any](t *testing.T, b *Batcher[K, V], n int) *pendingBatch[K, V] {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		b.mu.Lock()
+		batch := b.pending
+		ready := batch != nil && batch.loads == n
+		b.mu.Unlock()
+		if ready {
+			return batch
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d loads to join the batch", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBatcher(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]int
+	)
+	// the window never expires during the test, batches are dispatched by hand
+	b := NewBatcher(time.Hour, func(keys []int) (map[int]string, error) {
+		mu.Lock()
+		batches = append(batches, slices.Clone(keys))
+		mu.Unlock()
+		vals := make(map[int]string, len(keys))
+		for _, k := range keys {
+			if k != 99 {
+				vals[k] = string(rune('a' + k))
+			}
+		}
+		return vals, nil
+	})
+
+	keys := []int{0, 1, 2, 1, 3}
+	results := make([]string, len(keys))
+	var wg sync.WaitGroup
+	for i, k := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := b.Load(k)
+			if err != nil {
+				t.Errorf("unexpected error for %d: %v", k, err)
+			}
+			results[i] = v
+		}()
+	}
+	b.dispatch(waitForPending(t, b, len(keys)))
+	wg.Wait()
+
+	if len(batches) != 1 {
+		t.Fatalf("expected a single batch dispatch, got %v", batches)
+	}
+	if got := slices.Sorted(slices.Values(batches[0])); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Fatalf("expected deduplicated keys in batch, got %v", batches[0])
+	}
+	if !slices.Equal(results, []string{"a", "b", "c", "b", "d"}) {
+		t.Fatalf("unexpected results: %v", results)
+	}
+
+	// a later load starts a new batch, and missing keys are reported
+	errc := make(chan error, 1)
+	go func() {
+		_, err := b.Load(99)
+		errc <- err
+	}()
+	b.dispatch(waitForPending(t, b, 1))
+	if err := <-errc; err == nil {
+		t.Fatalf("expected error for missing key")
+	}
+	if len(batches) != 2 {
+		t.Fatalf("expected a second batch, got %v", batches)
+	}
+}
+
+func TestBatcherError(t *testing.T) {
+	boom := errors.New("boom")
+	b := NewBatcher(time.Millisecond, func([]string) (map[string]int, error) { return nil, boom })
+	if _, err := b.Load("x"); !errors.Is(err, boom) {
+		t.Fatalf("expected boom error, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package fn
+
+import "sync"
+
+// Buffer accumulates values and hands them to a flush callback in batches, either automatically
+// once size values are buffered or manually via Flush. It is safe for concurrent use. Batches are
+// delivered one at a time and in order, and the callback runs without the buffer's lock held, so it
+// may call Add and Len. It must not call Flush, or Add enough to trigger another flush, as that
+// waits for the running callback to return. When Add is called concurrently a batch may hold more
+// than size values.
+type Buffer[T any] struct {
+	flushMu sync.Mutex // held while the callback runs, keeps batches in order
+	mu      sync.Mutex
+	size    int
+	items   []T
+	flush   func([]T)
+}
+
+// NewBuffer returns a Buffer that calls flush whenever size values have been added.
+// A size <= 0 disables automatic flushing.
+func NewBuffer[T any](size int, flush func([]T)) *Buffer[T] {
+	return &Buffer[T]{size: size, flush: flush, items: make([]T, 0, max(size, 0))}
+}
+
+// Add appends v to the buffer, flushing it if it has reached its size
+func (b *Buffer[T]) Add(v T) {
+	b.mu.Lock()
+	b.items = append(b.items, v)
+	full := b.size > 0 && len(b.items) >= b.size
+	b.mu.Unlock()
+	if full {
+		b.Flush()
+	}
+}
+
+// Flush passes any buffered values to the flush callback, doing nothing if the buffer is empty
+func (b *Buffer[T]) Flush() {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	b.mu.Lock()
+	if len(b.items) == 0 {
+		b.mu.Unlock()
+		return
+	}
+	// hand off the current slice and start a new one, so the callback may keep its batch
+	batch := b.items
+	b.items = make([]T, 0, max(b.size, 0))
+	b.mu.Unlock()
+	b.flush(batch)
+}
+
+// Len returns the number of values currently buffered
+func (b *Buffer[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.items)
+}
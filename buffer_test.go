@@ -0,0 +1,59 @@
+package fn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBufferAutoFlush(t *testing.T) {
+	var flushed [][]int
+	buf := NewBuffer(3, func(batch []int) { flushed = append(flushed, batch) })
+	for i := 1; i <= 7; i++ {
+		buf.Add(i)
+	}
+	if !reflect.DeepEqual(flushed, [][]int{{1, 2, 3}, {4, 5, 6}}) {
+		t.Fatalf("auto flush mismatch: %v", flushed)
+	}
+	if buf.Len() != 1 {
+		t.Fatalf("expected 1 buffered value, got %d", buf.Len())
+	}
+}
+
+func TestBufferFlush(t *testing.T) {
+	var flushed [][]string
+	buf := NewBuffer(10, func(batch []string) { flushed = append(flushed, batch) })
+	buf.Add("a")
+	buf.Add("b")
+	buf.Flush()
+	if !reflect.DeepEqual(flushed, [][]string{{"a", "b"}}) {
+		t.Fatalf("manual flush mismatch: %v", flushed)
+	}
+	buf.Flush()
+	if len(flushed) != 1 {
+		t.Fatalf("expected flushing an empty buffer to do nothing")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected empty buffer after flush")
+	}
+}
+
+func TestBufferCallbackUsesBuffer(t *testing.T) {
+	var buf *Buffer[int]
+	var flushed [][]int
+	buf = NewBuffer(2, func(batch []int) {
+		// the callback runs without the buffer locked, so it can re-queue work
+		flushed = append(flushed, batch)
+		if buf.Len() == 0 && batch[0] < 10 {
+			buf.Add(batch[0] * 10)
+		}
+	})
+	for i := 1; i <= 3; i++ {
+		buf.Add(i)
+	}
+	if !reflect.DeepEqual(flushed, [][]int{{1, 2}, {10, 3}}) {
+		t.Fatalf("flush mismatch: %v", flushed)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected empty buffer, got %d", buf.Len())
+	}
+}
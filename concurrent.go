@@ -0,0 +1,286 @@
+package fn
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitedMap applies f to every element, starting calls at least 1/rps seconds apart with at
+// most workers calls in flight at once (workers <= 0 is treated as 1), and returns the results in
+// input order. The first error cancels the context passed to in-flight calls and is returned once
+// they have finished.
+func RateLimitedMap[T, R any](ctx context.Context, slice []T, rps, workers int, f func(context.Context, T) (R, error)) ([]R, error) {
+	return rateLimitedMap(ctx, slice, rps, workers, f, time.Now, time.After)
+}
+
+func rateLimitedMap[T, R any](ctx context.Context, slice []T, rps, workers int, f func(context.Context, T) (R, error), now func() time.Time, after func(time.Duration) <-chan time.Time) ([]R, error) {
+	if rps <= 0 {
+		return nil, fmt.Errorf("fn: rps must be positive, got %d", rps)
+	}
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// rates above one call per nanosecond are indistinguishable from no delay at all
+	interval := max(time.Second/time.Duration(rps), time.Nanosecond)
+
+	results := make([]R, len(slice))
+	sem := make(chan struct{}, max(workers, 1))
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		last     time.Time
+	)
+loop:
+	for i, v := range slice {
+		select {
+		case <-ctx.Done():
+			break loop
+		case sem <- struct{}{}:
+		}
+		// pace on the previous start rather than a ticker, so time spent waiting for a worker
+		// slot can't be banked into a burst of starts
+		if i > 0 {
+			if wait := last.Add(interval).Sub(now()); wait > 0 {
+				select {
+				case <-ctx.Done():
+					<-sem
+					break loop
+				case <-after(wait):
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		last = now()
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			r, err := f(ctx, v)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = r
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := parent.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// KeyedMutex provides a separate lock per key, so only callers using the same key serialize.
+// The zero value is ready to use. Locks for keys nobody holds or waits on are released.
+type KeyedMutex[K comparable] struct {
+	mu    sync.Mutex
+	locks map[K]*keyedLock
+}
+
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock locks the mutex for key k, blocking until it is available
+func (m *KeyedMutex[K]) Lock(k K) {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[K]*keyedLock)
+	}
+	l, ok := m.locks[k]
+	if !ok {
+		l = &keyedLock{}
+		m.locks[k] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+	l.mu.Lock()
+}
+
+// Unlock unlocks the mutex for key k. It panics if k is not locked.
+func (m *KeyedMutex[K]) Unlock(k K) {
+	m.mu.Lock()
+	l, ok := m.locks[k]
+	if !ok {
+		m.mu.Unlock()
+		panic(fmt.Sprintf("fn: unlock of unlocked key %v", k))
+	}
+	if l.refs--; l.refs == 0 {
+		delete(m.locks, k)
+	}
+	m.mu.Unlock()
+	l.mu.Unlock()
+}
+
+// SingleFlight wraps f so that concurrent calls with the same key share a single execution,
+// all receiving its result and error. Results are not cached once the call completes.
+// If f panics, the panic propagates in the calling goroutine and the callers that were
+// waiting on it receive an error instead of a zero result.
+func SingleFlight[K comparable, V any](f func(K) (V, error)) func(K) (V, error) {
+	g := &singleFlight[K, V]{f: f, calls: make(map[K]*flightCall[V])}
+	return g.do
+}
+
+type singleFlight[K comparable, V any] struct {
+	f     func(K) (V, error)
+	mu    sync.Mutex
+	calls map[K]*flightCall[V]
+}
+
+type flightCall[V any] struct {
+	wg      sync.WaitGroup
+	val     V
+	err     error
+	waiters int
+}
+
+func (g *singleFlight[K, V]) do(k K) (V, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[k]; ok {
+		c.waiters++
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+	c := &flightCall[V]{}
+	c.wg.Add(1)
+	g.calls[k] = c
+	g.mu.Unlock()
+
+	completed := false
+	defer func() {
+		var r any
+		if !completed {
+			// f panicked or called runtime.Goexit; don't hand waiters a zero result as if it succeeded
+			r = recover()
+			c.err = fmt.Errorf("fn: SingleFlight call for key %v did not complete: %v", k, r)
+		}
+		g.mu.Lock()
+		delete(g.calls, k)
+		g.mu.Unlock()
+		c.wg.Done()
+		if r != nil {
+			panic(r)
+		}
+	}()
+	c.val, c.err = g.f(k)
+	completed = true
+	return c.val, c.err
+}
+
+// ParallelEachBatch splits the slice into batches of size and processes them with up to workers
+// goroutines. The first error cancels the context passed to f, stops remaining batches from
+// starting and is returned. If the parent context is cancelled, its error is returned.
+func ParallelEachBatch[T any](ctx context.Context, slice []T, size, workers int, f func(context.Context, []T) error) error {
+	batches, err := BatchErr(slice, size)
+	if err != nil {
+		return err
+	}
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	work := make(chan []T)
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for range Clamp(workers, 1, max(len(batches), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range work {
+				// the send loop may still hand out a batch after cancellation, drop it
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := f(ctx, batch); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+loop:
+	for _, batch := range batches {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			break loop
+		case work <- batch:
+		}
+	}
+	close(work)
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return parent.Err()
+}
+
+// MapConcurrent applies f to every element using up to workers goroutines and never stops early.
+// Like TryMap, both returned slices are parallel to the input: results[i] is the zero value wherever
+// errs[i] is non-nil, and errs is nil if every call succeeded.
+func MapConcurrent[T, R any](slice []T, workers int, f func(T) (R, error)) (results []R, errs []error) {
+	results = make([]R, len(slice))
+	allErrs := make([]error, len(slice))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for range Clamp(workers, 1, max(len(slice), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				r, err := f(slice[i])
+				if err != nil {
+					allErrs[i] = err
+					continue
+				}
+				results[i] = r
+			}
+		}()
+	}
+	for i := range slice {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	for _, err := range allErrs {
+		if err != nil {
+			return results, allErrs
+		}
+	}
+	return results, nil
+}
+
+// ParallelTimes calls f(0) through f(n-1) using up to workers goroutines and returns the results
+// in index order
+func ParallelTimes[T any](n, workers int, f func(int) T) []T {
+	indices := make([]int, max(n, 0))
+	for i := range indices {
+		indices[i] = i
+	}
+	results, _ := MapConcurrent(indices, workers, func(i int) (T, error) { return f(i), nil })
+	return results
+}
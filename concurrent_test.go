@@ -0,0 +1,435 @@
+package fn
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manual clock whose timers fire as soon as they are requested,
+// advancing the clock by the requested duration
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+func TestRateLimitedMap(t *testing.T) {
+	const rps = 50
+	interval := time.Second / rps
+	data := []int{1, 2, 3, 4, 5, 6}
+	start := time.Now()
+	res, err := RateLimitedMap(context.Background(), data, rps, 2, func(_ context.Context, v int) (int, error) {
+		return v * 10, nil
+	})
+	if err != nil || !reflect.DeepEqual(res, []int{10, 20, 30, 40, 50, 60}) {
+		t.Fatalf("ratelimitedmap mismatch: %v %v", res, err)
+	}
+	if total := time.Since(start); total < time.Duration(len(data)-1)*interval {
+		t.Fatalf("calls completed too quickly: %v", total)
+	}
+}
+
+func TestRateLimitedMapPacing(t *testing.T) {
+	const rps = 10
+	interval := time.Second / rps
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var starts []time.Duration
+	// with a single worker the loop is blocked on the slot while f runs, so f sees its own start time
+	_, err := rateLimitedMap(context.Background(), []int{0, 1, 2, 3, 4}, rps, 1, func(_ context.Context, v int) (int, error) {
+		starts = append(starts, clock.Now().Sub(time.Unix(0, 0)))
+		if v == 0 {
+			// a slow call holds the only slot well past the next start time
+			clock.Advance(5 * interval / 2)
+		}
+		return v, nil
+	}, clock.Now, clock.After)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the late start isn't followed by a catch-up burst
+	want := []time.Duration{0, 5 * interval / 2, 7 * interval / 2, 9 * interval / 2, 11 * interval / 2}
+	if !reflect.DeepEqual(starts, want) {
+		t.Fatalf("unexpected start times: %v", starts)
+	}
+}
+
+func TestRateLimitedMapWorkers(t *testing.T) {
+	const workers = 2
+	var running, peak atomic.Int32
+	data := make([]int, 8)
+	_, err := RateLimitedMap(context.Background(), data, 1000, workers, func(_ context.Context, v int) (int, error) {
+		cur := running.Add(1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return v, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := peak.Load(); p > workers {
+		t.Fatalf("expected at most %d calls in flight, saw %d", workers, p)
+	}
+}
+
+func TestRateLimitedMapError(t *testing.T) {
+	boom := errors.New("boom")
+	_, err := RateLimitedMap(context.Background(), []int{1, 2, 3, 4}, 100, 2, func(_ context.Context, v int) (int, error) {
+		if v == 2 {
+			return 0, boom
+		}
+		return v, nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom error, got %v", err)
+	}
+	res, err := RateLimitedMap(context.Background(), []int{1, 2}, 2_000_000_000, 1, func(_ context.Context, v int) (int, error) { return v, nil })
+	if err != nil || !reflect.DeepEqual(res, []int{1, 2}) {
+		t.Fatalf("expected very high rps to work, got %v %v", res, err)
+	}
+	if _, err := RateLimitedMap(context.Background(), []int{1}, 0, 1, func(_ context.Context, v int) (int, error) { return v, nil }); err == nil {
+		t.Fatalf("expected error for rps 0")
+	}
+}
+
+func TestKeyedMutex(t *testing.T) {
+	var m KeyedMutex[string]
+	m.Lock("a")
+
+	// a different key proceeds while "a" is held
+	otherDone := make(chan struct{})
+	go func() {
+		m.Lock("b")
+		m.Unlock("b")
+		close(otherDone)
+	}()
+	select {
+	case <-otherDone:
+	case <-time.After(time.Second):
+		t.Fatalf("different key blocked by held lock")
+	}
+
+	// the same key waits until "a" is released
+	sameDone := make(chan struct{})
+	go func() {
+		m.Lock("a")
+		m.Unlock("a")
+		close(sameDone)
+	}()
+	select {
+	case <-sameDone:
+		t.Fatalf("same key acquired while lock held")
+	case <-time.After(50 * time.Millisecond):
+	}
+	m.Unlock("a")
+	select {
+	case <-sameDone:
+	case <-time.After(time.Second):
+		t.Fatalf("same key never acquired after unlock")
+	}
+	if len(m.locks) != 0 {
+		t.Fatalf("expected idle locks to be released, got %d", len(m.locks))
+	}
+}
+
+func TestKeyedMutexUnlockPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic when unlocking an unlocked key")
+		}
+	}()
+	var m KeyedMutex[int]
+	m.Unlock(1)
+}
+
+// waitForWaiters blocks until n callers are parked on the in-flight call for k
+func waitForWaiters[K comparable, V any](t *testing.T, g *singleFlight[K, V], k K, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		g.mu.Lock()
+		c, ok := g.calls[k]
+		waiting := ok && c.waiters == n
+		g.mu.Unlock()
+		if waiting {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d callers to join the in-flight call", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	g := &singleFlight[string, int]{
+		f: func(k string) (int, error) {
+			calls.Add(1)
+			<-release
+			return len(k), nil
+		},
+		calls: make(map[string]*flightCall[int]),
+	}
+
+	const callers = 10
+	results := make([]int, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := g.do("hello")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = v
+		}()
+	}
+	// one caller runs f, the rest must be parked on it before it is released
+	waitForWaiters(t, g, "hello", callers-1)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected f to run once, ran %d times", n)
+	}
+	for i, v := range results {
+		if v != 5 {
+			t.Fatalf("caller %d got %d", i, v)
+		}
+	}
+	// once complete, the next call runs f again
+	if _, err := g.do("hello"); err != nil || calls.Load() != 2 {
+		t.Fatalf("expected a fresh call after completion")
+	}
+}
+
+func TestSingleFlightPanic(t *testing.T) {
+	release := make(chan struct{})
+	g := &singleFlight[int, string]{
+		f: func(int) (string, error) {
+			<-release
+			panic("boom")
+		},
+		calls: make(map[int]*flightCall[string]),
+	}
+
+	leaderPanic := make(chan any, 1)
+	go func() {
+		defer func() { leaderPanic <- recover() }()
+		g.do(1)
+	}()
+	// wait for the leader to start before joining as a waiter
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		g.mu.Lock()
+		_, ok := g.calls[1]
+		g.mu.Unlock()
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the leader call")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	waiterErr := make(chan error, 1)
+	go func() {
+		_, err := g.do(1)
+		waiterErr <- err
+	}()
+	waitForWaiters(t, g, 1, 1)
+	close(release)
+
+	if r := <-leaderPanic; r != "boom" {
+		t.Fatalf("expected the panic to propagate to the leader, got %v", r)
+	}
+	if err := <-waiterErr; err == nil {
+		t.Fatalf("expected waiter to get an error after the panic")
+	}
+	// the failed call must not linger
+	if len(g.calls) != 0 {
+		t.Fatalf("expected no in-flight calls after the panic")
+	}
+}
+
+func TestSingleFlightError(t *testing.T) {
+	boom := errors.New("boom")
+	load := SingleFlight(func(int) (string, error) { return "", boom })
+	if _, err := load(1); !errors.Is(err, boom) {
+		t.Fatalf("expected boom error, got %v", err)
+	}
+}
+
+func TestParallelEachBatch(t *testing.T) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i + 1
+	}
+	var sum atomic.Int64
+	var batches atomic.Int32
+	err := ParallelEachBatch(context.Background(), data, 10, 4, func(_ context.Context, batch []int) error {
+		batches.Add(1)
+		for _, v := range batch {
+			sum.Add(int64(v))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batches.Load() != 10 || sum.Load() != 5050 {
+		t.Fatalf("expected 10 batches summing to 5050, got %d batches sum %d", batches.Load(), sum.Load())
+	}
+}
+
+func TestParallelEachBatchError(t *testing.T) {
+	boom := errors.New("boom")
+	var processed atomic.Int32
+	err := ParallelEachBatch(context.Background(), make([]int, 100), 1, 2, func(ctx context.Context, batch []int) error {
+		if processed.Add(1) == 3 {
+			return boom
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Millisecond):
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom error, got %v", err)
+	}
+	if n := processed.Load(); n >= 100 {
+		t.Fatalf("expected remaining batches to be skipped, processed %d", n)
+	}
+
+	// with a single worker nothing is in flight alongside the failing batch,
+	// so no batch may start after it
+	processed.Store(0)
+	err = ParallelEachBatch(context.Background(), make([]int, 100), 1, 1, func(ctx context.Context, batch []int) error {
+		if processed.Add(1) == 3 {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom error, got %v", err)
+	}
+	if n := processed.Load(); n != 3 {
+		t.Fatalf("expected no batch to start after the failure, processed %d", n)
+	}
+}
+
+func TestParallelEachBatchCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var processed atomic.Int32
+	err := ParallelEachBatch(ctx, make([]int, 100), 1, 2, func(ctx context.Context, batch []int) error {
+		if processed.Add(1) == 5 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if n := processed.Load(); n >= 100 {
+		t.Fatalf("expected cancellation to stop processing, processed %d", n)
+	}
+	if err := ParallelEachBatch(context.Background(), []int{1}, 0, 2, func(context.Context, []int) error { return nil }); err == nil {
+		t.Fatalf("expected error for size 0")
+	}
+}
+
+func TestMapConcurrent(t *testing.T) {
+	data := []string{"1", "a", "3", "b", "5", "c"}
+	var calls atomic.Int32
+	results, errs := MapConcurrent(data, 3, func(s string) (int, error) {
+		calls.Add(1)
+		return strconv.Atoi(s)
+	})
+	if calls.Load() != int32(len(data)) {
+		t.Fatalf("expected every element processed, got %d calls", calls.Load())
+	}
+	if !reflect.DeepEqual(results, []int{1, 0, 3, 0, 5, 0}) {
+		t.Fatalf("results mismatch: %v", results)
+	}
+	if len(errs) != len(data) {
+		t.Fatalf("expected parallel error slice, got %v", errs)
+	}
+	for i, err := range errs {
+		if failed := i%2 == 1; failed != (err != nil) {
+			t.Fatalf("unexpected error state at %d: %v", i, err)
+		}
+	}
+	if _, errs := MapConcurrent([]string{"1", "2"}, 0, strconv.Atoi); errs != nil {
+		t.Fatalf("expected nil errors on success, got %v", errs)
+	}
+}
+
+func TestParallelTimes(t *testing.T) {
+	const n, workers = 20, 5
+	var running, peak atomic.Int32
+	start := time.Now()
+	got := ParallelTimes(n, workers, func(i int) int {
+		cur := running.Add(1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return i * i
+	})
+	elapsed := time.Since(start)
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("result %d out of order: %d", i, v)
+		}
+	}
+	if len(got) != n {
+		t.Fatalf("expected %d results got %d", n, len(got))
+	}
+	if p := peak.Load(); p > workers {
+		t.Fatalf("expected at most %d concurrent calls, saw %d", workers, p)
+	}
+	if elapsed >= n*10*time.Millisecond {
+		t.Fatalf("expected calls to overlap, took %v", elapsed)
+	}
+	if got := ParallelTimes(0, workers, func(i int) int { return i }); len(got) != 0 {
+		t.Fatalf("expected no results for n=0")
+	}
+}
@@ -0,0 +1,55 @@
+package fn
+
+import "slices"
+
+// Counter counts occurrences of comparable values. The zero value is ready to use.
+type Counter[T comparable] struct {
+	counts map[T]int
+	order  []T
+	total  int
+}
+
+// NewCounter returns a Counter pre-populated with the elements of values
+func NewCounter[T comparable](values ...T) *Counter[T] {
+	c := &Counter[T]{}
+	for _, v := range values {
+		c.Add(v)
+	}
+	return c
+}
+
+// Add increments the count of v by one
+func (c *Counter[T]) Add(v T) {
+	if c.counts == nil {
+		c.counts = make(map[T]int)
+	}
+	if _, ok := c.counts[v]; !ok {
+		c.order = append(c.order, v)
+	}
+	c.counts[v]++
+	c.total++
+}
+
+// Count returns the number of times v has been added
+func (c *Counter[T]) Count(v T) int {
+	return c.counts[v]
+}
+
+// Total returns the number of values added across all keys
+func (c *Counter[T]) Total() int {
+	return c.total
+}
+
+// TopK returns up to k values with the highest counts in descending order.
+// Ties are broken by the order in which values were first added.
+func (c *Counter[T]) TopK(k int) []Pair[T, int] {
+	if k <= 0 {
+		return nil
+	}
+	result := make([]Pair[T, int], len(c.order))
+	for i, v := range c.order {
+		result[i] = Pair[T, int]{First: v, Second: c.counts[v]}
+	}
+	slices.SortStableFunc(result, func(a, b Pair[T, int]) int { return b.Second - a.Second })
+	return Limit(result, k)
+}
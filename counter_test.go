@@ -0,0 +1,34 @@
+package fn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	var c Counter[string]
+	for _, v := range []string{"a", "b", "a", "c", "a", "b"} {
+		c.Add(v)
+	}
+	if c.Count("a") != 3 || c.Count("b") != 2 || c.Count("c") != 1 || c.Count("z") != 0 {
+		t.Fatalf("count mismatch")
+	}
+	if c.Total() != 6 {
+		t.Fatalf("expected total 6 got %d", c.Total())
+	}
+}
+
+func TestCounterTopK(t *testing.T) {
+	c := NewCounter("x", "y", "z", "y", "z", "w")
+	// y and z tie at 2, y was added first
+	expected := []Pair[string, int]{{"y", 2}, {"z", 2}, {"x", 1}}
+	if got := c.TopK(3); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("topk mismatch: %v", got)
+	}
+	if got := c.TopK(10); len(got) != 4 {
+		t.Fatalf("expected k clamped to 4 entries, got %v", got)
+	}
+	if c.TopK(0) != nil {
+		t.Fatalf("expected nil for k=0")
+	}
+}
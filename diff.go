@@ -0,0 +1,66 @@
+package fn
+
+// EditOp is the kind of change an Edit makes
+type EditOp int
+
+const (
+	// EditInsert inserts Value at Index
+	EditInsert EditOp = iota
+	// EditDelete removes Value from Index
+	EditDelete
+)
+
+// String returns "insert" or "delete"
+func (op EditOp) String() string {
+	switch op {
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// Edit is a single step of an edit script. Index is the position in the slice as it stands after
+// all previous edits in the script have been applied.
+type Edit[T any] struct {
+	Op    EditOp
+	Index int
+	Value T
+}
+
+// EditScript returns a minimal sequence of inserts and deletes that transforms a into b, computed
+// from their longest common subsequence. Applying the edits in order to a yields b. Within a
+// replaced region, deletions come before insertions. Uses O(len(a)*len(b)) time and memory.
+func EditScript[T comparable](a, b []T) []Edit[T] {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var edits []Edit[T]
+	i, j, pos := 0, 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i, j, pos = i+1, j+1, pos+1
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, Edit[T]{Op: EditDelete, Index: pos, Value: a[i]})
+			i++
+		default:
+			edits = append(edits, Edit[T]{Op: EditInsert, Index: pos, Value: b[j]})
+			j, pos = j+1, pos+1
+		}
+	}
+	return edits
+}
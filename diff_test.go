@@ -0,0 +1,64 @@
+package fn
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func applyEdits[T any](slice []T, edits []Edit[T]) []T {
+	result := slices.Clone(slice)
+	for _, e := range edits {
+		switch e.Op {
+		case EditInsert:
+			result = slices.Insert(result, e.Index, e.Value)
+		case EditDelete:
+			result = slices.Delete(result, e.Index, e.Index+1)
+		}
+	}
+	return result
+}
+
+func TestEditScriptInsert(t *testing.T) {
+	a, b := []string{"a", "c"}, []string{"a", "b", "c"}
+	edits := EditScript(a, b)
+	if !reflect.DeepEqual(edits, []Edit[string]{{EditInsert, 1, "b"}}) {
+		t.Fatalf("insert script mismatch: %v", edits)
+	}
+	if got := applyEdits(a, edits); !reflect.DeepEqual(got, b) {
+		t.Fatalf("applying edits gave %v", got)
+	}
+}
+
+func TestEditScriptDelete(t *testing.T) {
+	a, b := []int{1, 2, 3, 4}, []int{1, 3, 4}
+	edits := EditScript(a, b)
+	if !reflect.DeepEqual(edits, []Edit[int]{{EditDelete, 1, 2}}) {
+		t.Fatalf("delete script mismatch: %v", edits)
+	}
+	if got := applyEdits(a, edits); !reflect.DeepEqual(got, b) {
+		t.Fatalf("applying edits gave %v", got)
+	}
+}
+
+func TestEditScriptReplace(t *testing.T) {
+	a, b := []int{1, 2, 3}, []int{1, 9, 3}
+	edits := EditScript(a, b)
+	if !reflect.DeepEqual(edits, []Edit[int]{{EditDelete, 1, 2}, {EditInsert, 1, 9}}) {
+		t.Fatalf("replace script mismatch: %v", edits)
+	}
+	if got := applyEdits(a, edits); !reflect.DeepEqual(got, b) {
+		t.Fatalf("applying edits gave %v", got)
+	}
+}
+
+func TestEditScriptRoundTrip(t *testing.T) {
+	a := []rune("kitten sitting")
+	b := []rune("sitting kitten")
+	if got := applyEdits(a, EditScript(a, b)); string(got) != string(b) {
+		t.Fatalf("round trip mismatch: %q", string(got))
+	}
+	if edits := EditScript(a, a); len(edits) != 0 {
+		t.Fatalf("expected no edits for equal slices, got %v", edits)
+	}
+}
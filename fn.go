@@ -2,8 +2,11 @@ package fn
 
 import (
 	"cmp"
+	"errors"
+	"fmt"
 	"math/rand"
 	"slices"
+	"strings"
 )
 
 // Clamp constrains a value to be within the specified minimum and maximum bounds.
@@ -17,12 +20,36 @@ func Clamp[T cmp.Ordered](value, min, max T) T {
 	return value
 }
 
+// ClampIndex constrains index to a valid position in a sequence of the given length.
+// Returns 0 when length is 0, even though there is no valid position.
+func ClampIndex(index, length int) int {
+	if length <= 0 {
+		return 0
+	}
+	return Clamp(index, 0, length-1)
+}
+
+// WrapIndex wraps index into [0, length) using Euclidean modulo, so negative indices wrap
+// around from the end. Returns 0 when length <= 0.
+func WrapIndex(index, length int) int {
+	if length <= 0 {
+		return 0
+	}
+	return ((index % length) + length) % length
+}
+
 // Limit returns a new slice containing at most n elements from the input slice.
 // If n is greater than the length of the slice, returns the entire slice.
 func Limit[T any](slice []T, n int) []T {
 	return slice[:min(len(slice), n)]
 }
 
+// LastN returns a subslice containing at most the last n elements of the input slice.
+// n is clamped to [0, len(slice)].
+func LastN[T any](slice []T, n int) []T {
+	return slice[len(slice)-Clamp(n, 0, len(slice)):]
+}
+
 // Map performs an in-place transformation of the slice if the input and output types are the same
 func Map[T, R any](slice []T, f func(T) R) []R {
 	result := make([]R, len(slice))
@@ -52,7 +79,29 @@ func Filter[T any](slice []T, pred func(T) bool) []T {
 	return result
 }
 
-// FilterInPlace filters a slice in-place without allocation, modifying and returning the original slice
+// FilterIndexed returns a new slice containing only the elements whose index and value satisfy the predicate
+func FilterIndexed[T any](slice []T, pred func(int, T) bool) []T {
+	result := make([]T, 0, len(slice))
+	for i, x := range slice {
+		if pred(i, x) {
+			result = append(result, x)
+		}
+	}
+	return result
+}
+
+// CopyFilter returns a new slice containing only the elements that satisfy the predicate.
+// Unlike Filter, the result never shares memory with the input and has no spare capacity.
+func CopyFilter[T any](slice []T, pred func(T) bool) []T {
+	matches := Filter(slice, pred)
+	result := make([]T, len(matches))
+	copy(result, matches)
+	return result
+}
+
+// FilterInPlace filters a slice in-place without allocation, modifying and returning the original slice.
+// The elements past the new length are zeroed so they don't keep references alive; clear compiles to a
+// memclr, so this is cheap even for element types without pointers.
 func FilterInPlace[T any](a []T, f func(T) bool) []T {
 	b := a[:0]
 	for _, x := range a {
@@ -61,10 +110,20 @@ func FilterInPlace[T any](a []T, f func(T) bool) []T {
 		}
 	}
 	// Clear remaining elements for garbage collection
-	var zero T
-	for i := len(b); i < len(a); i++ {
-		a[i] = zero
+	clear(a[len(b):])
+	return b
+}
+
+// FilterInPlaceIndexed is like FilterInPlace but passes each element's index to the predicate
+func FilterInPlaceIndexed[T any](a []T, f func(int, T) bool) []T {
+	b := a[:0]
+	for i, x := range a {
+		if f(i, x) {
+			b = append(b, x)
+		}
 	}
+	// Clear remaining elements for garbage collection
+	clear(a[len(b):])
 	return b
 }
 
@@ -77,6 +136,29 @@ func Reduce[T, R any](slice []T, initial R, f func(R, T) R) R {
 	return result
 }
 
+// ReduceIndexed reduces the slice passing each element's index to the reducer
+func ReduceIndexed[T, R any](slice []T, initial R, f func(R, int, T) R) R {
+	result := initial
+	for i := range slice {
+		result = f(result, i, slice[i])
+	}
+	return result
+}
+
+// ReduceErr reduces the slice like Reduce, stopping at the first error and returning it
+// together with the value accumulated before the failing element
+func ReduceErr[T, R any](slice []T, initial R, f func(R, T) (R, error)) (R, error) {
+	result := initial
+	for i := range slice {
+		next, err := f(result, slice[i])
+		if err != nil {
+			return result, err
+		}
+		result = next
+	}
+	return result, nil
+}
+
 // Any returns true if any element satisfies the predicate
 func Any[T any](slice []T, pred func(T) bool) bool {
 	return slices.ContainsFunc(slice, pred)
@@ -108,13 +190,33 @@ func Unique[T comparable](slice []T) []T {
 		n++
 	}
 	// Clear remaining elements to help GC of references
-	var zero T
-	for i := n; i < len(slice); i++ {
-		slice[i] = zero
-	}
+	clear(slice[n:])
 	return slice[:n]
 }
 
+// UniqueLast modifies the slice in-place to remove duplicates, keeping the last occurrence of each
+// value and preserving the relative order of those occurrences.
+func UniqueLast[T comparable](slice []T) []T {
+	if len(slice) <= 1 {
+		return slice
+	}
+	seen := make(map[T]struct{}, len(slice))
+	n := len(slice)
+	for i := len(slice) - 1; i >= 0; i-- {
+		v := slice[i]
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		n--
+		slice[n] = v
+	}
+	kept := copy(slice, slice[n:])
+	// Clear remaining elements to help GC of references
+	clear(slice[kept:])
+	return slice[:kept]
+}
+
 // IfElse provides a conditional operator that returns trueVal if condition is true, falseVal otherwise
 func IfElse[T any](condition bool, trueVal, falseVal T) T {
 	if condition {
@@ -123,6 +225,38 @@ func IfElse[T any](condition bool, trueVal, falseVal T) T {
 	return falseVal
 }
 
+// IsOneOf returns true if value equals any of the allowed values
+func IsOneOf[T comparable](value T, allowed ...T) bool {
+	return slices.Contains(allowed, value)
+}
+
+// Case is a single branch of Switch
+type Case[T, R any] struct {
+	When func(T) bool
+	Then R
+}
+
+// Switch returns the Then of the first case whose When matches value, or def if none match
+func Switch[T, R any](value T, cases []Case[T, R], def R) R {
+	for _, c := range cases {
+		if c.When(value) {
+			return c.Then
+		}
+	}
+	return def
+}
+
+// Zero returns the zero value of T
+func Zero[T any]() T {
+	var zero T
+	return zero
+}
+
+// IsZero returns true if v is the zero value of its type
+func IsZero[T comparable](v T) bool {
+	return v == Zero[T]()
+}
+
 // Reverse reverses the elements of a slice in-place
 func Reverse[T any](a []T) {
 	for left, right := 0, len(a)-1; left < right; left, right = left+1, right-1 {
@@ -130,6 +264,13 @@ func Reverse[T any](a []T) {
 	}
 }
 
+// ReverseForEach calls f on each element from last to first without modifying the slice
+func ReverseForEach[T any](slice []T, f func(T)) {
+	for i := len(slice) - 1; i >= 0; i-- {
+		f(slice[i])
+	}
+}
+
 // Shuffle randomly reorders the elements in a slice using Fisher-Yates algorithm
 func Shuffle[T any](a []T) {
 	for i := len(a) - 1; i > 0; i-- {
@@ -138,7 +279,8 @@ func Shuffle[T any](a []T) {
 	}
 }
 
-// Batch splits a slice into batches of specified size with minimal allocation
+// Batch splits a slice into batches of specified size with minimal allocation.
+// Returns nil if batchSize <= 0; use BatchErr to tell that apart from an empty input.
 func Batch[T any](slice []T, batchSize int) [][]T {
 	if batchSize <= 0 {
 		return nil
@@ -154,6 +296,14 @@ func Batch[T any](slice []T, batchSize int) [][]T {
 	return batches
 }
 
+// BatchErr is like Batch but returns an error instead of nil when batchSize <= 0
+func BatchErr[T any](slice []T, batchSize int) ([][]T, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("fn: batch size must be positive, got %d", batchSize)
+	}
+	return Batch(slice, batchSize), nil
+}
+
 // First returns the first element that satisfies the predicate
 func First[T any](slice []T, pred func(T) bool) (T, bool) {
 	for _, x := range slice {
@@ -165,6 +315,49 @@ func First[T any](slice []T, pred func(T) bool) (T, bool) {
 	return zero, false
 }
 
+// MustFirst returns the first element that satisfies the predicate, panicking if there is none
+func MustFirst[T any](slice []T, pred func(T) bool) T {
+	v, ok := First(slice, pred)
+	if !ok {
+		panic("fn: MustFirst found no element matching the predicate")
+	}
+	return v
+}
+
+// MustGet returns v, panicking if ok is false. It accepts any (T, bool) return directly:
+// v := fn.MustGet(fn.First(slice, pred))
+func MustGet[T any](v T, ok bool) T {
+	if !ok {
+		panic("fn: MustGet called with ok == false")
+	}
+	return v
+}
+
+// Must returns v, panicking if err is non-nil. Useful for initializing package-level variables:
+// var re = fn.Must(regexp.Compile(`^\d+$`))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Must2 is like Must for functions returning two values and an error
+func Must2[A, B any](a A, b B, err error) (A, B) {
+	if err != nil {
+		panic(err)
+	}
+	return a, b
+}
+
+// Must3 is like Must for functions returning three values and an error
+func Must3[A, B, C any](a A, b B, c C, err error) (A, B, C) {
+	if err != nil {
+		panic(err)
+	}
+	return a, b, c
+}
+
 // Delete removes all occurrences of an element from a slice
 // Warning! You must reassign the slice to the result of this function:
 // slice = fn.Delete(slice, value)
@@ -176,3 +369,730 @@ func Delete[T comparable](slice []T, value T) []T {
 func ToIfaceSlice(arr ...any) []any {
 	return arr
 }
+
+// TrimPrefix returns slice without the leading prefix, or slice unchanged if it doesn't start with prefix
+func TrimPrefix[T comparable](slice, prefix []T) []T {
+	if len(prefix) <= len(slice) && slices.Equal(slice[:len(prefix)], prefix) {
+		return slice[len(prefix):]
+	}
+	return slice
+}
+
+// TrimSuffix returns slice without the trailing suffix, or slice unchanged if it doesn't end with suffix
+func TrimSuffix[T comparable](slice, suffix []T) []T {
+	if n := len(slice) - len(suffix); n >= 0 && slices.Equal(slice[n:], suffix) {
+		return slice[:n]
+	}
+	return slice
+}
+
+// Cut splits the slice around the first occurrence of sep, excluding sep from both halves.
+// If sep is not present, it returns the whole slice as before, an empty after, and false.
+func Cut[T comparable](slice []T, sep T) (before, after []T, found bool) {
+	if i := slices.Index(slice, sep); i >= 0 {
+		return slice[:i], slice[i+1:], true
+	}
+	return slice, nil, false
+}
+
+// Apply replaces every element with the result of f in-place, without allocating
+func Apply[T any](slice []T, f func(T) T) {
+	for i, v := range slice {
+		slice[i] = f(v)
+	}
+}
+
+// Pair holds two values of possibly different types
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// WithIndex returns a new slice pairing each element with its index
+func WithIndex[T any](slice []T) []Pair[int, T] {
+	result := make([]Pair[int, T], len(slice))
+	for i, v := range slice {
+		result[i] = Pair[int, T]{First: i, Second: v}
+	}
+	return result
+}
+
+// MaxOf returns the largest of the given values
+func MaxOf[T cmp.Ordered](first T, rest ...T) T {
+	result := first
+	for _, v := range rest {
+		if v > result {
+			result = v
+		}
+	}
+	return result
+}
+
+// MinOf returns the smallest of the given values
+func MinOf[T cmp.Ordered](first T, rest ...T) T {
+	result := first
+	for _, v := range rest {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
+
+// SampleSeed returns k distinct elements chosen at random using a local RNG seeded with seed.
+// The input slice is not modified and the global RNG state is left untouched.
+func SampleSeed[T any](slice []T, k int, seed int64) []T {
+	return sample(slice, k, rand.New(rand.NewSource(seed)))
+}
+
+// ChoiceSeed returns a random element chosen using a local RNG seeded with seed
+func ChoiceSeed[T any](slice []T, seed int64) (T, bool) {
+	if len(slice) == 0 {
+		var zero T
+		return zero, false
+	}
+	return slice[rand.New(rand.NewSource(seed)).Intn(len(slice))], true
+}
+
+// sample runs a partial Fisher-Yates shuffle over a copy of the slice
+func sample[T any](slice []T, k int, r *rand.Rand) []T {
+	k = Clamp(k, 0, len(slice))
+	result := slices.Clone(slice)
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(len(result)-i)
+		result[i], result[j] = result[j], result[i]
+	}
+	return result[:k:k]
+}
+
+// ChunkByWeight splits a slice into chunks whose total weight does not exceed maxWeight.
+// An element heavier than maxWeight on its own is placed in a chunk by itself.
+func ChunkByWeight[T any](slice []T, maxWeight int, weigh func(T) int) [][]T {
+	var chunks [][]T
+	start, weight := 0, 0
+	for i, v := range slice {
+		w := weigh(v)
+		if i > start && weight+w > maxWeight {
+			chunks = append(chunks, slice[start:i:i])
+			start, weight = i, 0
+		}
+		weight += w
+	}
+	if start < len(slice) {
+		chunks = append(chunks, slice[start:])
+	}
+	return chunks
+}
+
+// Transpose swaps the rows and columns of a matrix.
+// Ragged input is truncated to the length of the shortest row; use TransposeStrict to reject it instead.
+func Transpose[T any](matrix [][]T) [][]T {
+	if len(matrix) == 0 {
+		return nil
+	}
+	cols := len(matrix[0])
+	for _, row := range matrix[1:] {
+		cols = min(cols, len(row))
+	}
+	result := make([][]T, cols)
+	for j := range result {
+		result[j] = make([]T, len(matrix))
+		for i, row := range matrix {
+			result[j][i] = row[j]
+		}
+	}
+	return result
+}
+
+// TransposeStrict is like Transpose but returns an error if the rows differ in length
+func TransposeStrict[T any](matrix [][]T) ([][]T, error) {
+	for i, row := range matrix {
+		if len(row) != len(matrix[0]) {
+			return nil, fmt.Errorf("fn: ragged matrix, row %d has length %d, expected %d", i, len(row), len(matrix[0]))
+		}
+	}
+	return Transpose(matrix), nil
+}
+
+// Product returns every combination of an element from a with an element from b
+func Product[A, B any](a []A, b []B) []Pair[A, B] {
+	result := make([]Pair[A, B], 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			result = append(result, Pair[A, B]{First: x, Second: y})
+		}
+	}
+	return result
+}
+
+// Permutations returns all orderings of the slice using Heap's algorithm.
+// Warning! The result grows factorially: n elements produce n! permutations.
+func Permutations[T any](slice []T) [][]T {
+	perm := slices.Clone(slice)
+	result := [][]T{slices.Clone(perm)}
+	c := make([]int, len(perm))
+	for i := 1; i < len(perm); {
+		if c[i] < i {
+			if i%2 == 0 {
+				perm[0], perm[i] = perm[i], perm[0]
+			} else {
+				perm[c[i]], perm[i] = perm[i], perm[c[i]]
+			}
+			result = append(result, slices.Clone(perm))
+			c[i]++
+			i = 1
+		} else {
+			c[i] = 0
+			i++
+		}
+	}
+	return result
+}
+
+// Combinations returns all k-element combinations of the slice, preserving input order within each.
+// k <= 0 returns a single empty combination; k > len(slice) returns nil.
+func Combinations[T any](slice []T, k int) [][]T {
+	if k <= 0 {
+		return [][]T{{}}
+	}
+	if k > len(slice) {
+		return nil
+	}
+	var result [][]T
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	for {
+		comb := make([]T, k)
+		for i, j := range idx {
+			comb[i] = slice[j]
+		}
+		result = append(result, comb)
+		// advance the rightmost index that still has room to move
+		i := k - 1
+		for i >= 0 && idx[i] == len(slice)-k+i {
+			i--
+		}
+		if i < 0 {
+			return result
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// PowerSet returns all 2^n subsets of the slice, preserving input order within each subset.
+// Warning! The result grows exponentially with the length of the slice.
+func PowerSet[T any](slice []T) [][]T {
+	result := make([][]T, 1, 1<<len(slice))
+	result[0] = []T{}
+	for _, v := range slice {
+		for _, subset := range result {
+			result = append(result, append(slices.Clip(subset), v))
+		}
+	}
+	return result
+}
+
+// Span splits the slice at the first element that fails the predicate,
+// returning the longest satisfying prefix and the rest as views into the original slice
+func Span[T any](slice []T, pred func(T) bool) (prefix, rest []T) {
+	for i, v := range slice {
+		if !pred(v) {
+			return slice[:i], slice[i:]
+		}
+	}
+	return slice, slice[len(slice):]
+}
+
+// Break splits the slice at the first element that satisfies the predicate,
+// the same as Span with the predicate negated
+func Break[T any](slice []T, pred func(T) bool) (prefix, rest []T) {
+	return Span(slice, func(v T) bool { return !pred(v) })
+}
+
+// EachPair calls f once for every unordered pair of elements, with i < j
+func EachPair[T any](slice []T, f func(i, j int, a, b T)) {
+	for i := range slice {
+		for j := i + 1; j < len(slice); j++ {
+			f(i, j, slice[i], slice[j])
+		}
+	}
+}
+
+// MapBatched splits the slice into batches of size, calls f on each batch and concatenates the results.
+// f is expected to return one result per input element, but results are concatenated as-is,
+// so a batch function that returns fewer (or more) results is allowed. Returns nil if size <= 0.
+func MapBatched[T, R any](slice []T, size int, f func([]T) []R) []R {
+	if size <= 0 {
+		return nil
+	}
+	result := make([]R, 0, len(slice))
+	for _, batch := range Batch(slice, size) {
+		result = append(result, f(batch)...)
+	}
+	return result
+}
+
+// CompactMap applies f to each element and keeps only the results for which f returns true
+func CompactMap[T, R any](slice []T, f func(T) (R, bool)) []R {
+	result := make([]R, 0, len(slice))
+	for _, v := range slice {
+		if r, ok := f(v); ok {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// WeightedChoice returns an item chosen with probability proportional to its weight.
+// Returns false if the lengths differ, any weight is negative, or all weights are zero.
+func WeightedChoice[T any](items []T, weights []float64, r *rand.Rand) (T, bool) {
+	var zero T
+	if len(items) != len(weights) {
+		return zero, false
+	}
+	var total float64
+	for _, w := range weights {
+		if w < 0 {
+			return zero, false
+		}
+		total += w
+	}
+	if total == 0 {
+		return zero, false
+	}
+	target := r.Float64() * total
+	for i, w := range weights {
+		if target < w {
+			return items[i], true
+		}
+		target -= w
+	}
+	// floating-point rounding may leave target just past the last positive weight
+	for i := len(weights) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return items[i], true
+		}
+	}
+	return zero, false
+}
+
+// ReduceBatched reduces each batch of size independently starting from initial, then merges the
+// partial results in order. initial must be an identity for combine and merge (e.g. 0 for sums),
+// since it seeds every batch. A size <= 0 reduces the whole slice as a single batch.
+func ReduceBatched[T, R any](slice []T, size int, initial R, combine func(R, T) R, merge func(R, R) R) R {
+	if size <= 0 || len(slice) <= size {
+		return Reduce(slice, initial, combine)
+	}
+	batches := Batch(slice, size)
+	result := Reduce(batches[0], initial, combine)
+	for _, batch := range batches[1:] {
+		result = merge(result, Reduce(batch, initial, combine))
+	}
+	return result
+}
+
+// IsSorted returns true if the slice is sorted in ascending order
+func IsSorted[T cmp.Ordered](slice []T) bool {
+	return slices.IsSorted(slice)
+}
+
+// IsSortedBy returns true if no element is less than its predecessor according to less
+func IsSortedBy[T any](slice []T, less func(a, b T) bool) bool {
+	for i := 1; i < len(slice); i++ {
+		if less(slice[i], slice[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// BinarySearchBy searches a slice sorted by keyFn for target, returning the index where it was found
+// or where it would be inserted, and whether an exact match was found
+func BinarySearchBy[T any, K cmp.Ordered](slice []T, target K, keyFn func(T) K) (int, bool) {
+	return slices.BinarySearchFunc(slice, target, func(el T, t K) int { return cmp.Compare(keyFn(el), t) })
+}
+
+// InsertSorted inserts value into a sorted slice at the position that keeps it sorted
+// Warning! You must reassign the slice to the result of this function:
+// slice = fn.InsertSorted(slice, value)
+func InsertSorted[T cmp.Ordered](slice []T, value T) []T {
+	i, _ := slices.BinarySearch(slice, value)
+	return slices.Insert(slice, i, value)
+}
+
+// RotateLeftCopy returns a new slice with the elements rotated left by k positions.
+// k may exceed the length of the slice, and a negative k rotates right.
+func RotateLeftCopy[T any](slice []T, k int) []T {
+	result := make([]T, len(slice))
+	if len(slice) == 0 {
+		return result
+	}
+	k = WrapIndex(k, len(slice))
+	n := copy(result, slice[k:])
+	copy(result[n:], slice[:k])
+	return result
+}
+
+// RotateRightCopy returns a new slice with the elements rotated right by k positions.
+// k may exceed the length of the slice, and a negative k rotates left.
+func RotateRightCopy[T any](slice []T, k int) []T {
+	return RotateLeftCopy(slice, -k)
+}
+
+// Enumerate returns the indices of the slice alongside a copy of its values
+func Enumerate[T any](slice []T) (indices []int, values []T) {
+	indices = make([]int, len(slice))
+	for i := range indices {
+		indices[i] = i
+	}
+	values = make([]T, len(slice))
+	copy(values, slice)
+	return indices, values
+}
+
+// JoinToString formats each element with format and joins the results with sep
+func JoinToString[T any](slice []T, sep string, format func(T) string) string {
+	var sb strings.Builder
+	for i, v := range slice {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(format(v))
+	}
+	return sb.String()
+}
+
+// Window returns all overlapping windows of length size, advancing one element at a time.
+// Returns nil if size <= 0 or size is greater than the length of the slice.
+func Window[T any](slice []T, size int) [][]T {
+	return WindowStep(slice, size, 1)
+}
+
+// WindowStep returns windows of length size, advancing by step elements each time.
+// Windows are views into the original slice; a trailing partial window is dropped.
+// Returns nil if size <= 0 or step <= 0.
+func WindowStep[T any](slice []T, size, step int) [][]T {
+	if size <= 0 || step <= 0 || size > len(slice) {
+		return nil
+	}
+	windows := make([][]T, 0, (len(slice)-size)/step+1)
+	for i := 0; i+size <= len(slice); i += step {
+		windows = append(windows, slice[i:i+size:i+size])
+	}
+	return windows
+}
+
+// TryMap applies f to every element without stopping at errors. Both returned slices are
+// parallel to the input: results[i] is the zero value wherever errs[i] is non-nil.
+// errs is nil if every call succeeded.
+func TryMap[T, R any](slice []T, f func(T) (R, error)) (results []R, errs []error) {
+	results = make([]R, len(slice))
+	for i, v := range slice {
+		r, err := f(v)
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(slice))
+			}
+			errs[i] = err
+			continue
+		}
+		results[i] = r
+	}
+	return results, errs
+}
+
+// BatchBalanced splits a slice into exactly count batches whose sizes differ by at most one,
+// with the earlier batches taking the extra elements. If count exceeds the length of the slice,
+// the trailing batches are empty. Returns nil if count <= 0.
+func BatchBalanced[T any](slice []T, count int) [][]T {
+	if count <= 0 {
+		return nil
+	}
+	batches := make([][]T, count)
+	size, extra := len(slice)/count, len(slice)%count
+	start := 0
+	for i := range batches {
+		end := start + size
+		if i < extra {
+			end++
+		}
+		batches[i] = slice[start:end:end]
+		start = end
+	}
+	return batches
+}
+
+// TopoSort orders items so that every item comes after the items it depends on.
+// keyFn identifies each item and depsFn lists the keys it depends on. Items with no
+// ordering constraint between them keep their relative input order where possible.
+// Returns an error on duplicate keys, unknown dependencies, or cycles.
+func TopoSort[T any, K comparable](items []T, keyFn func(T) K, depsFn func(T) []K) ([]T, error) {
+	index := make(map[K]int, len(items))
+	for i, item := range items {
+		k := keyFn(item)
+		if _, ok := index[k]; ok {
+			return nil, fmt.Errorf("fn: duplicate key %v", k)
+		}
+		index[k] = i
+	}
+	indegree := make([]int, len(items))
+	dependents := make([][]int, len(items))
+	for i, item := range items {
+		for _, dep := range Unique(slices.Clone(depsFn(item))) {
+			j, ok := index[dep]
+			if !ok {
+				return nil, fmt.Errorf("fn: %v depends on unknown key %v", keyFn(item), dep)
+			}
+			indegree[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+	queue := make([]int, 0, len(items))
+	for i, d := range indegree {
+		if d == 0 {
+			queue = append(queue, i)
+		}
+	}
+	result := make([]T, 0, len(items))
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		result = append(result, items[i])
+		for _, j := range dependents[i] {
+			if indegree[j]--; indegree[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+	if len(result) != len(items) {
+		return nil, errors.New("fn: dependency cycle detected")
+	}
+	return result, nil
+}
+
+// SlidingReduce returns the reduction of every sliding window of length size, each starting from initial.
+// Returns nil if size <= 0 or size is greater than the length of the slice.
+func SlidingReduce[T, R any](slice []T, size int, initial R, f func(R, T) R) []R {
+	if size <= 0 || size > len(slice) {
+		return nil
+	}
+	result := make([]R, len(slice)-size+1)
+	for i := range result {
+		result[i] = Reduce(slice[i:i+size], initial, f)
+	}
+	return result
+}
+
+// SplitN splits a slice into at most n contiguous parts whose sizes differ by at most one.
+// Unlike BatchBalanced, it never returns empty parts. Returns nil if n <= 0.
+func SplitN[T any](slice []T, n int) [][]T {
+	if n <= 0 {
+		return nil
+	}
+	return BatchBalanced(slice, min(n, len(slice)))
+}
+
+// DefaultIfEmpty returns slice if it is non-empty, otherwise a new slice containing defaults
+func DefaultIfEmpty[T any](slice []T, defaults ...T) []T {
+	if len(slice) > 0 {
+		return slice
+	}
+	return append([]T(nil), defaults...)
+}
+
+// GroupBy groups the elements of a slice by the key returned by keyFn, preserving order within each group
+func GroupBy[T any, K comparable](slice []T, keyFn func(T) K) map[K][]T {
+	return GroupBySeq(slices.Values(slice), keyFn)
+}
+
+// Clone returns a shallow copy of the slice; the elements themselves are not copied
+func Clone[T any](slice []T) []T {
+	return slices.Clone(slice)
+}
+
+// EachBatchWhile calls fn with consecutive batches of size until fn returns false.
+// fn is never called if size <= 0.
+func EachBatchWhile[T any](slice []T, size int, fn func(batch []T) bool) {
+	if size <= 0 {
+		return
+	}
+	for len(slice) > 0 {
+		n := min(size, len(slice))
+		if !fn(slice[:n:n]) {
+			return
+		}
+		slice = slice[n:]
+	}
+}
+
+// ChunkWhile splits a slice into chunks, keeping each element in the current chunk while
+// sameChunk(prev, curr) holds and starting a new chunk otherwise
+func ChunkWhile[T any](slice []T, sameChunk func(prev, curr T) bool) [][]T {
+	if len(slice) == 0 {
+		return nil
+	}
+	var chunks [][]T
+	start := 0
+	for i := 1; i < len(slice); i++ {
+		if !sameChunk(slice[i-1], slice[i]) {
+			chunks = append(chunks, slice[start:i:i])
+			start = i
+		}
+	}
+	return append(chunks, slice[start:])
+}
+
+// UniqueWithDuplicates returns the first occurrence of each value in order, along with every
+// further occurrence that was dropped. The input slice is not modified.
+func UniqueWithDuplicates[T comparable](slice []T) (unique, dupes []T) {
+	seen := make(map[T]struct{}, len(slice))
+	for _, v := range slice {
+		if _, ok := seen[v]; ok {
+			dupes = append(dupes, v)
+			continue
+		}
+		seen[v] = struct{}{}
+		unique = append(unique, v)
+	}
+	return unique, dupes
+}
+
+// SliceToPointers returns pointers to copies of each element, so the input slice is never aliased
+func SliceToPointers[T any](slice []T) []*T {
+	values := slices.Clone(slice)
+	result := make([]*T, len(values))
+	for i := range values {
+		result[i] = &values[i]
+	}
+	return result
+}
+
+// PointersToSlice dereferences each pointer, using the zero value for nil pointers
+func PointersToSlice[T any](ptrs []*T) []T {
+	result := make([]T, len(ptrs))
+	for i, p := range ptrs {
+		if p != nil {
+			result[i] = *p
+		}
+	}
+	return result
+}
+
+// BatchKeepGroups splits a slice into batches of at most maxSize elements without splitting a run
+// of adjacent elements with equal keys across two batches. A run longer than maxSize is placed in
+// its own oversized batch. Returns nil if maxSize <= 0.
+func BatchKeepGroups[T any, K comparable](slice []T, maxSize int, keyFn func(T) K) [][]T {
+	if maxSize <= 0 {
+		return nil
+	}
+	var batches [][]T
+	start, end := 0, 0
+	for _, run := range ChunkWhile(slice, func(prev, curr T) bool { return keyFn(prev) == keyFn(curr) }) {
+		if end > start && end-start+len(run) > maxSize {
+			batches = append(batches, slice[start:end:end])
+			start = end
+		}
+		end += len(run)
+	}
+	if end > start {
+		batches = append(batches, slice[start:end])
+	}
+	return batches
+}
+
+// MapWithState maps each element while threading a state value through the calls,
+// returning the results and the final state
+func MapWithState[T, R, S any](slice []T, initial S, f func(S, T) (S, R)) ([]R, S) {
+	result := make([]R, len(slice))
+	state := initial
+	for i, v := range slice {
+		state, result[i] = f(state, v)
+	}
+	return result, state
+}
+
+// FilterBatches filters the elements within each batch and drops batches left empty
+func FilterBatches[T any](batches [][]T, pred func(T) bool) [][]T {
+	result := make([][]T, 0, len(batches))
+	for _, batch := range batches {
+		if filtered := Filter(batch, pred); len(filtered) > 0 {
+			result = append(result, filtered)
+		}
+	}
+	return result
+}
+
+// Get returns the element at index and true, or the zero value and false if index is out of range
+func Get[T any](slice []T, index int) (T, bool) {
+	if index < 0 || index >= len(slice) {
+		var zero T
+		return zero, false
+	}
+	return slice[index], true
+}
+
+// GetOr returns the element at index, or def if index is out of range
+func GetOr[T any](slice []T, index int, def T) T {
+	if v, ok := Get(slice, index); ok {
+		return v
+	}
+	return def
+}
+
+// At returns the element at index, where negative indices count from the end (-1 is the last element).
+// Returns the zero value and false if index is out of range in either direction.
+func At[T any](slice []T, index int) (T, bool) {
+	if index < 0 {
+		index += len(slice)
+	}
+	return Get(slice, index)
+}
+
+// TruncateWithMore returns at most n elements. If the slice is longer than n, the last kept element
+// is replaced with more(remaining), where remaining is the number of elements it stands in for.
+// The input slice is not modified.
+func TruncateWithMore[T any](slice []T, n int, more func(remaining int) T) []T {
+	if n <= 0 {
+		return []T{}
+	}
+	if len(slice) <= n {
+		return slice
+	}
+	result := make([]T, n)
+	copy(result, slice[:n-1])
+	result[n-1] = more(len(slice) - (n - 1))
+	return result
+}
+
+// BatchIndices returns the [start, end) offsets of each batch of size over a sequence of length,
+// without touching any data. Returns nil if size <= 0.
+func BatchIndices(length, size int) [][2]int {
+	if size <= 0 {
+		return nil
+	}
+	var result [][2]int
+	for start := 0; start < length; start += size {
+		result = append(result, [2]int{start, min(start+size, length)})
+	}
+	return result
+}
+
+// GroupReduce groups the elements by the key returned by keyFn and reduces each group to a single
+// value starting from initial, like SQL's GROUP BY with an aggregate
+func GroupReduce[T any, K comparable, R any](slice []T, keyFn func(T) K, initial R, f func(R, T) R) map[K]R {
+	result := make(map[K]R)
+	for _, v := range slice {
+		k := keyFn(v)
+		acc, ok := result[k]
+		if !ok {
+			acc = initial
+		}
+		result[k] = f(acc, v)
+	}
+	return result
+}
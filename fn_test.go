@@ -1,8 +1,12 @@
 package fn
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
 	"reflect"
+	"slices"
+	"strconv"
 	"testing"
 )
 
@@ -18,6 +22,36 @@ func TestClamp(t *testing.T) {
 	}
 }
 
+func TestClampIndex(t *testing.T) {
+	if ClampIndex(-2, 5) != 0 {
+		t.Fatalf("below range not clamped")
+	}
+	if ClampIndex(3, 5) != 3 {
+		t.Fatalf("in range index changed")
+	}
+	if ClampIndex(9, 5) != 4 {
+		t.Fatalf("above range not clamped")
+	}
+	if ClampIndex(2, 0) != 0 {
+		t.Fatalf("expected 0 for zero length")
+	}
+}
+
+func TestWrapIndex(t *testing.T) {
+	if WrapIndex(-1, 5) != 4 {
+		t.Fatalf("expected -1 to wrap to 4")
+	}
+	if WrapIndex(7, 5) != 2 {
+		t.Fatalf("expected 7 to wrap to 2")
+	}
+	if WrapIndex(3, 5) != 3 || WrapIndex(-10, 5) != 0 {
+		t.Fatalf("wrapindex mismatch")
+	}
+	if WrapIndex(3, 0) != 0 {
+		t.Fatalf("expected 0 for zero length")
+	}
+}
+
 func TestLimit(t *testing.T) {
 	data := []int{1, 2, 3}
 	if got := Limit(data, 2); !reflect.DeepEqual(got, []int{1, 2}) {
@@ -31,6 +65,22 @@ func TestLimit(t *testing.T) {
 	}
 }
 
+func TestLastN(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	if got := LastN(data, 2); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Fatalf("lastn mismatch: %v", got)
+	}
+	if got := LastN(data, 10); !reflect.DeepEqual(got, data) {
+		t.Fatalf("lastn beyond len mismatch: %v", got)
+	}
+	if got := LastN(data, 0); len(got) != 0 {
+		t.Fatalf("expected empty slice for n=0")
+	}
+	if got := LastN(data, -1); len(got) != 0 {
+		t.Fatalf("expected empty slice for negative n")
+	}
+}
+
 func TestMap(t *testing.T) {
 	data := []int{1, 2, 3}
 	res := Map(data, func(v int) int { return v * v })
@@ -63,6 +113,32 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestFilterIndexed(t *testing.T) {
+	data := []string{"a", "b", "c", "d", "e"}
+	res := FilterIndexed(data, func(i int, _ string) bool { return i%2 == 0 })
+	if !reflect.DeepEqual(res, []string{"a", "c", "e"}) {
+		t.Fatalf("filterindexed mismatch: %v", res)
+	}
+	if !reflect.DeepEqual(data, []string{"a", "b", "c", "d", "e"}) {
+		t.Fatalf("original slice modified")
+	}
+}
+
+func TestCopyFilter(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	res := CopyFilter(data, func(v int) bool { return v%3 == 0 })
+	if !reflect.DeepEqual(res, []int{3, 6}) {
+		t.Fatalf("copyfilter mismatch: %v", res)
+	}
+	if cap(res) != len(res) {
+		t.Fatalf("expected no spare capacity, got len %d cap %d", len(res), cap(res))
+	}
+	res[0] = 100
+	if data[2] != 3 {
+		t.Fatalf("original slice modified")
+	}
+}
+
 func TestFilterInPlace(t *testing.T) {
 	data := []int{1, 2, 3, 4, 5}
 	filtered := FilterInPlace(data, func(v int) bool { return v > 2 })
@@ -75,6 +151,32 @@ func TestFilterInPlace(t *testing.T) {
 	}
 }
 
+func TestFilterInPlaceClearsTail(t *testing.T) {
+	a, b, c := 1, 2, 3
+	data := []*int{&a, &b, &c}
+	filtered := FilterInPlace(data, func(p *int) bool { return *p != 2 })
+	if len(filtered) != 2 || *filtered[0] != 1 || *filtered[1] != 3 {
+		t.Fatalf("filter in place mismatch: %v", filtered)
+	}
+	if data[2] != nil {
+		t.Fatalf("expected trailing element to be cleared")
+	}
+}
+
+func TestFilterInPlaceIndexed(t *testing.T) {
+	data := []string{"a", "b", "c", "d", "e"}
+	filtered := FilterInPlaceIndexed(data, func(i int, _ string) bool { return i%2 == 0 })
+	if !reflect.DeepEqual(filtered, []string{"a", "c", "e"}) {
+		t.Fatalf("filter in place indexed mismatch: %v", filtered)
+	}
+	if cap(filtered) != cap(data) {
+		t.Fatalf("expected reuse of underlying array")
+	}
+	if data[3] != "" || data[4] != "" {
+		t.Fatalf("expected trailing elements to be cleared: %v", data)
+	}
+}
+
 func TestReduce(t *testing.T) {
 	data := []int{1, 2, 3, 4}
 	sum := Reduce(data, 0, func(acc, v int) int { return acc + v })
@@ -87,6 +189,37 @@ func TestReduce(t *testing.T) {
 	}
 }
 
+func TestReduceIndexed(t *testing.T) {
+	data := []int{5, 3, 2}
+	// weight each value by its position (1-based)
+	sum := ReduceIndexed(data, 0, func(acc, i, v int) int { return acc + (i+1)*v })
+	if sum != 5+6+6 {
+		t.Fatalf("expected weighted sum 17 got %d", sum)
+	}
+}
+
+func TestReduceErr(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	calls := 0
+	sum, err := ReduceErr(data, 0, func(acc, v int) (int, error) {
+		calls++
+		if v == 3 {
+			return 0, fmt.Errorf("bad value %d", v)
+		}
+		return acc + v, nil
+	})
+	if err == nil || err.Error() != "bad value 3" {
+		t.Fatalf("expected error on third element, got %v", err)
+	}
+	if sum != 3 || calls != 3 {
+		t.Fatalf("expected accumulator 3 after 3 calls, got %d after %d", sum, calls)
+	}
+	sum, err = ReduceErr(data, 0, func(acc, v int) (int, error) { return acc + v, nil })
+	if err != nil || sum != 10 {
+		t.Fatalf("expected sum 10 got %d %v", sum, err)
+	}
+}
+
 func TestAnyAll(t *testing.T) {
 	data := []int{1, 3, 5}
 	if Any(data, func(v int) bool { return v%2 == 0 }) {
@@ -116,6 +249,17 @@ func TestUnique(t *testing.T) {
 	}
 }
 
+func TestUniqueLast(t *testing.T) {
+	cases := [][]int{{}, {1}, {1, 1, 1}, {1, 2, 1, 3}, {1, 2, 3, 2, 1}}
+	expects := [][]int{{}, {1}, {1}, {2, 1, 3}, {3, 2, 1}}
+	for i, c := range cases {
+		got := UniqueLast(c)
+		if !reflect.DeepEqual(got, expects[i]) {
+			t.Fatalf("uniquelast mismatch case %d: %v != %v", i, got, expects[i])
+		}
+	}
+}
+
 func TestIfElse(t *testing.T) {
 	if IfElse(true, 1, 2) != 1 {
 		t.Fatalf("expected 1")
@@ -125,6 +269,47 @@ func TestIfElse(t *testing.T) {
 	}
 }
 
+func TestIsOneOf(t *testing.T) {
+	if !IsOneOf("b", "a", "b", "c") {
+		t.Fatalf("expected member")
+	}
+	if IsOneOf("z", "a", "b", "c") {
+		t.Fatalf("expected non-member")
+	}
+	if IsOneOf("a") {
+		t.Fatalf("expected false for empty allowed list")
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	cases := []Case[int, string]{
+		{When: func(v int) bool { return v < 0 }, Then: "negative"},
+		{When: func(v int) bool { return v == 0 }, Then: "zero"},
+		{When: func(v int) bool { return v < 10 }, Then: "small"},
+	}
+	for v, expected := range map[int]string{-5: "negative", 0: "zero", 3: "small", 42: "large"} {
+		if got := Switch(v, cases, "large"); got != expected {
+			t.Fatalf("switch(%d) = %q, expected %q", v, got, expected)
+		}
+	}
+}
+
+func TestZero(t *testing.T) {
+	type point struct{ X, Y int }
+	if Zero[int]() != 0 || Zero[string]() != "" || Zero[point]() != (point{}) {
+		t.Fatalf("zero value mismatch")
+	}
+	if !IsZero(0) || IsZero(1) {
+		t.Fatalf("int iszero mismatch")
+	}
+	if !IsZero("") || IsZero("a") {
+		t.Fatalf("string iszero mismatch")
+	}
+	if !IsZero(point{}) || IsZero(point{Y: 1}) {
+		t.Fatalf("struct iszero mismatch")
+	}
+}
+
 func TestReverse(t *testing.T) {
 	data := []int{1, 2, 3, 4, 5}
 	Reverse(data)
@@ -141,6 +326,18 @@ func TestReverse(t *testing.T) {
 	Reverse(empty)
 }
 
+func TestReverseForEach(t *testing.T) {
+	data := []int{1, 2, 3}
+	var order []int
+	ReverseForEach(data, func(v int) { order = append(order, v) })
+	if !reflect.DeepEqual(order, []int{3, 2, 1}) {
+		t.Fatalf("reverseforeach order mismatch: %v", order)
+	}
+	if !reflect.DeepEqual(data, []int{1, 2, 3}) {
+		t.Fatalf("original slice modified")
+	}
+}
+
 func TestShuffle(t *testing.T) {
 	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
 	copyData := append([]int(nil), data...)
@@ -204,6 +401,23 @@ func TestBatch(t *testing.T) {
 	}
 }
 
+func TestBatchErr(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	batches, err := BatchErr(data, 2)
+	if err != nil || !reflect.DeepEqual(batches, [][]int{{1, 2}, {3, 4}, {5}}) {
+		t.Fatalf("batcherr mismatch: %v %v", batches, err)
+	}
+	if _, err := BatchErr(data, 0); err == nil || err.Error() != "fn: batch size must be positive, got 0" {
+		t.Fatalf("unexpected error for size 0: %v", err)
+	}
+	if _, err := BatchErr(data, -1); err == nil || err.Error() != "fn: batch size must be positive, got -1" {
+		t.Fatalf("unexpected error for size -1: %v", err)
+	}
+	if batches, err := BatchErr([]int{}, 3); err != nil || batches != nil {
+		t.Fatalf("expected no batches and no error for empty input: %v %v", batches, err)
+	}
+}
+
 func TestFirst(t *testing.T) {
 	data := []int{5, 7, 9, 10}
 	if v, ok := First(data, func(x int) bool { return x%2 == 0 }); !ok || v != 10 {
@@ -214,6 +428,72 @@ func TestFirst(t *testing.T) {
 	}
 }
 
+func expectPanic(t *testing.T, msg string, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		if r := recover(); r != msg {
+			t.Fatalf("expected panic %q got %v", msg, r)
+		}
+	}()
+	f()
+}
+
+func TestMustFirst(t *testing.T) {
+	data := []int{5, 7, 10}
+	if v := MustFirst(data, func(x int) bool { return x%2 == 0 }); v != 10 {
+		t.Fatalf("expected 10 got %d", v)
+	}
+	expectPanic(t, "fn: MustFirst found no element matching the predicate", func() {
+		MustFirst(data, func(x int) bool { return x < 0 })
+	})
+}
+
+func TestMustGet(t *testing.T) {
+	data := []int{5, 7, 10}
+	if v := MustGet(First(data, func(x int) bool { return x > 6 })); v != 7 {
+		t.Fatalf("expected 7 got %d", v)
+	}
+	expectPanic(t, "fn: MustGet called with ok == false", func() {
+		MustGet(First(data, func(x int) bool { return x < 0 }))
+	})
+}
+
+func TestMust(t *testing.T) {
+	if v := Must(strconv.Atoi("42")); v != 42 {
+		t.Fatalf("expected 42 got %d", v)
+	}
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("expected panic with the parse error, got %v", err)
+		}
+	}()
+	Must(strconv.Atoi("x"))
+}
+
+func TestMust2Must3(t *testing.T) {
+	two := func(fail bool) (int, string, error) {
+		if fail {
+			return 0, "", errors.New("boom")
+		}
+		return 1, "a", nil
+	}
+	if a, b := Must2(two(false)); a != 1 || b != "a" {
+		t.Fatalf("must2 passthrough mismatch: %v %v", a, b)
+	}
+	if a, b, c := Must3(1, "a", true, nil); a != 1 || b != "a" || !c {
+		t.Fatalf("must3 passthrough mismatch")
+	}
+	boom := errors.New("boom")
+	defer func() {
+		if r := recover(); r != boom {
+			t.Fatalf("expected panic with boom, got %v", r)
+		}
+	}()
+	Must3(1, "a", true, boom)
+}
+
 func TestDelete(t *testing.T) {
 	data := []int{1, 2, 3, 2, 4}
 	res := Delete(data, 2)
@@ -241,3 +521,733 @@ func TestToIfaceSlice(t *testing.T) {
 		t.Fatalf("expected empty slice")
 	}
 }
+
+func TestTrimPrefixSuffix(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	if got := TrimPrefix(data, []int{1, 2}); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Fatalf("trim prefix mismatch: %v", got)
+	}
+	if got := TrimPrefix(data, []int{2, 3}); !reflect.DeepEqual(got, data) {
+		t.Fatalf("non-matching prefix should be left intact: %v", got)
+	}
+	if got := TrimPrefix(data, nil); !reflect.DeepEqual(got, data) {
+		t.Fatalf("empty prefix should be a no-op: %v", got)
+	}
+	if got := TrimSuffix(data, []int{3, 4}); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("trim suffix mismatch: %v", got)
+	}
+	if got := TrimSuffix(data, []int{2, 3}); !reflect.DeepEqual(got, data) {
+		t.Fatalf("non-matching suffix should be left intact: %v", got)
+	}
+	if got := TrimSuffix(data, []int{}); !reflect.DeepEqual(got, data) {
+		t.Fatalf("empty suffix should be a no-op: %v", got)
+	}
+	if got := TrimPrefix(data, []int{1, 2, 3, 4, 5}); !reflect.DeepEqual(got, data) {
+		t.Fatalf("prefix longer than slice should be a no-op: %v", got)
+	}
+}
+
+func TestCut(t *testing.T) {
+	data := []int{1, 2, 0, 3, 0, 4}
+	before, after, found := Cut(data, 0)
+	if !found || !reflect.DeepEqual(before, []int{1, 2}) || !reflect.DeepEqual(after, []int{3, 0, 4}) {
+		t.Fatalf("cut mismatch: %v %v %v", before, after, found)
+	}
+	before, after, found = Cut(data, 1)
+	if !found || len(before) != 0 || !reflect.DeepEqual(after, []int{2, 0, 3, 0, 4}) {
+		t.Fatalf("cut at index 0 mismatch: %v %v %v", before, after, found)
+	}
+	before, after, found = Cut(data, 9)
+	if found || !reflect.DeepEqual(before, data) || len(after) != 0 {
+		t.Fatalf("expected whole slice before absent separator: %v %v %v", before, after, found)
+	}
+}
+
+func TestApply(t *testing.T) {
+	data := make([]int, 4, 8)
+	copy(data, []int{1, 2, 3, 4})
+	Apply(data, func(v int) int { return v * 2 })
+	if !reflect.DeepEqual(data, []int{2, 4, 6, 8}) {
+		t.Fatalf("apply mismatch: %v", data)
+	}
+	if cap(data) != 8 {
+		t.Fatalf("expected reuse of underlying array")
+	}
+}
+
+func TestWithIndex(t *testing.T) {
+	data := []string{"a", "b", "c"}
+	res := WithIndex(data)
+	if len(res) != len(data) {
+		t.Fatalf("length mismatch")
+	}
+	for i, p := range res {
+		if p.First != i || p.Second != data[i] {
+			t.Fatalf("pair %d mismatch: %v", i, p)
+		}
+	}
+}
+
+func TestMaxOfMinOf(t *testing.T) {
+	if got := MaxOf(3, 9, -2, 7); got != 9 {
+		t.Fatalf("expected max 9 got %d", got)
+	}
+	if got := MinOf(3, 9, -2, 7); got != -2 {
+		t.Fatalf("expected min -2 got %d", got)
+	}
+	if MaxOf(42) != 42 || MinOf(42) != 42 {
+		t.Fatalf("single argument should be returned as-is")
+	}
+}
+
+func TestSampleSeed(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	a := SampleSeed(data, 4, 42)
+	b := SampleSeed(data, 4, 42)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("expected identical samples for identical seeds: %v != %v", a, b)
+	}
+	if len(a) != 4 || len(Unique(slices.Clone(a))) != 4 {
+		t.Fatalf("expected 4 distinct elements: %v", a)
+	}
+	if !reflect.DeepEqual(data, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Fatalf("original slice modified")
+	}
+	if got := SampleSeed(data, 20, 1); len(got) != len(data) {
+		t.Fatalf("expected k clamped to length, got %d", len(got))
+	}
+}
+
+func TestChoiceSeed(t *testing.T) {
+	data := []string{"a", "b", "c", "d"}
+	a, okA := ChoiceSeed(data, 7)
+	b, okB := ChoiceSeed(data, 7)
+	if !okA || !okB || a != b {
+		t.Fatalf("expected identical choices for identical seeds: %v != %v", a, b)
+	}
+	if _, ok := ChoiceSeed([]string{}, 7); ok {
+		t.Fatalf("expected no choice from empty slice")
+	}
+}
+
+func TestChunkByWeight(t *testing.T) {
+	words := []string{"ab", "cde", "f", "ghij", "k", "lm"}
+	chunks := ChunkByWeight(words, 5, func(s string) int { return len(s) })
+	expected := [][]string{{"ab", "cde"}, {"f", "ghij"}, {"k", "lm"}}
+	if !reflect.DeepEqual(chunks, expected) {
+		t.Fatalf("chunkbyweight mismatch: %v", chunks)
+	}
+	// oversized element goes in its own chunk
+	sizes := ChunkByWeight([]int{1, 10, 2, 2}, 4, func(v int) int { return v })
+	if !reflect.DeepEqual(sizes, [][]int{{1}, {10}, {2, 2}}) {
+		t.Fatalf("oversized element mismatch: %v", sizes)
+	}
+	if ChunkByWeight([]int{}, 4, func(v int) int { return v }) != nil {
+		t.Fatalf("expected nil for empty input")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	matrix := [][]int{{1, 2, 3}, {4, 5, 6}}
+	if got := Transpose(matrix); !reflect.DeepEqual(got, [][]int{{1, 4}, {2, 5}, {3, 6}}) {
+		t.Fatalf("transpose mismatch: %v", got)
+	}
+	// ragged input truncates to the shortest row
+	if got := Transpose([][]int{{1, 2, 3}, {4}}); !reflect.DeepEqual(got, [][]int{{1, 4}}) {
+		t.Fatalf("ragged transpose mismatch: %v", got)
+	}
+	if Transpose([][]int{}) != nil {
+		t.Fatalf("expected nil for empty matrix")
+	}
+}
+
+func TestTransposeStrict(t *testing.T) {
+	got, err := TransposeStrict([][]int{{1, 2, 3}, {4, 5, 6}})
+	if err != nil || !reflect.DeepEqual(got, [][]int{{1, 4}, {2, 5}, {3, 6}}) {
+		t.Fatalf("strict transpose mismatch: %v %v", got, err)
+	}
+	if _, err := TransposeStrict([][]int{{1, 2}, {3}}); err == nil {
+		t.Fatalf("expected error for ragged matrix")
+	}
+}
+
+func TestProduct(t *testing.T) {
+	res := Product([]int{1, 2, 3}, []string{"a", "b"})
+	if len(res) != 6 {
+		t.Fatalf("expected 6 pairs got %d", len(res))
+	}
+	if res[0] != (Pair[int, string]{1, "a"}) || res[1] != (Pair[int, string]{1, "b"}) || res[5] != (Pair[int, string]{3, "b"}) {
+		t.Fatalf("product mismatch: %v", res)
+	}
+	if len(Product([]int{}, []string{"a"})) != 0 {
+		t.Fatalf("expected no pairs for empty input")
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	perms := Permutations([]int{1, 2, 3})
+	if len(perms) != 6 {
+		t.Fatalf("expected 6 permutations got %d", len(perms))
+	}
+	seen := map[[3]int]bool{}
+	for _, p := range perms {
+		key := [3]int{p[0], p[1], p[2]}
+		if seen[key] {
+			t.Fatalf("duplicate permutation %v", p)
+		}
+		seen[key] = true
+	}
+	if got := Permutations([]int{}); len(got) != 1 || len(got[0]) != 0 {
+		t.Fatalf("expected one empty permutation, got %v", got)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	combs := Combinations([]string{"a", "b", "c", "d"}, 2)
+	expected := [][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}}
+	if !reflect.DeepEqual(combs, expected) {
+		t.Fatalf("combinations mismatch: %v", combs)
+	}
+	if got := Combinations([]int{1, 2}, 0); len(got) != 1 || len(got[0]) != 0 {
+		t.Fatalf("expected single empty combination for k=0, got %v", got)
+	}
+	if got := Combinations([]int{1, 2}, 3); got != nil {
+		t.Fatalf("expected nil for k > len, got %v", got)
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	sets := PowerSet([]int{1, 2, 3})
+	if len(sets) != 8 {
+		t.Fatalf("expected 8 subsets got %d", len(sets))
+	}
+	expected := [][]int{{}, {1}, {2}, {1, 2}, {3}, {1, 3}, {2, 3}, {1, 2, 3}}
+	if !reflect.DeepEqual(sets, expected) {
+		t.Fatalf("powerset mismatch: %v", sets)
+	}
+}
+
+func TestSpan(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	prefix, rest := Span(data, func(v int) bool { return v > 5 })
+	if len(prefix) != 0 || !reflect.DeepEqual(rest, data) {
+		t.Fatalf("immediate failure mismatch: %v %v", prefix, rest)
+	}
+	prefix, rest = Span(data, func(v int) bool { return v > 0 })
+	if !reflect.DeepEqual(prefix, data) || len(rest) != 0 {
+		t.Fatalf("always-true mismatch: %v %v", prefix, rest)
+	}
+	prefix, rest = Span(data, func(v int) bool { return v < 3 })
+	if !reflect.DeepEqual(prefix, []int{1, 2}) || !reflect.DeepEqual(rest, []int{3, 4}) {
+		t.Fatalf("midway mismatch: %v %v", prefix, rest)
+	}
+}
+
+func TestBreak(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	prefix, rest := Break(data, func(v int) bool { return v > 0 })
+	if len(prefix) != 0 || !reflect.DeepEqual(rest, data) {
+		t.Fatalf("immediate match mismatch: %v %v", prefix, rest)
+	}
+	prefix, rest = Break(data, func(v int) bool { return v > 5 })
+	if !reflect.DeepEqual(prefix, data) || len(rest) != 0 {
+		t.Fatalf("never-true mismatch: %v %v", prefix, rest)
+	}
+	prefix, rest = Break(data, func(v int) bool { return v >= 3 })
+	if !reflect.DeepEqual(prefix, []int{1, 2}) || !reflect.DeepEqual(rest, []int{3, 4}) {
+		t.Fatalf("midway mismatch: %v %v", prefix, rest)
+	}
+}
+
+func TestEachPair(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	calls := 0
+	EachPair(data, func(i, j int, a, b int) {
+		if i >= j || a != data[i] || b != data[j] {
+			t.Fatalf("unexpected pair (%d, %d) = (%d, %d)", i, j, a, b)
+		}
+		calls++
+	})
+	if n := len(data); calls != n*(n-1)/2 {
+		t.Fatalf("expected %d calls got %d", n*(n-1)/2, calls)
+	}
+}
+
+func TestMapBatched(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	var sizes []int
+	res := MapBatched(data, 2, func(batch []int) []string {
+		sizes = append(sizes, len(batch))
+		return Map(batch, func(v int) string { return string(rune('a' + v - 1)) })
+	})
+	if !reflect.DeepEqual(res, []string{"a", "b", "c", "d", "e"}) {
+		t.Fatalf("mapbatched mismatch: %v", res)
+	}
+	if !reflect.DeepEqual(sizes, []int{2, 2, 1}) {
+		t.Fatalf("unexpected batch sizes: %v", sizes)
+	}
+	if MapBatched(data, 0, func(batch []int) []int { return batch }) != nil {
+		t.Fatalf("expected nil for size 0")
+	}
+}
+
+func TestCompactMap(t *testing.T) {
+	data := []string{"1", "x", "2", "", "3"}
+	res := CompactMap(data, func(s string) (int, bool) {
+		n, err := strconv.Atoi(s)
+		return n, err == nil
+	})
+	if !reflect.DeepEqual(res, []int{1, 2, 3}) {
+		t.Fatalf("compactmap mismatch: %v", res)
+	}
+}
+
+func TestWeightedChoice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	items := []string{"a", "b", "c"}
+	weights := []float64{1, 3, 0}
+	counts := map[string]int{}
+	const draws = 10000
+	for range draws {
+		v, ok := WeightedChoice(items, weights, r)
+		if !ok {
+			t.Fatalf("expected a choice")
+		}
+		counts[v]++
+	}
+	if counts["c"] != 0 {
+		t.Fatalf("zero-weight item chosen %d times", counts["c"])
+	}
+	if ratio := float64(counts["b"]) / draws; ratio < 0.72 || ratio > 0.78 {
+		t.Fatalf("expected b chosen ~75%% of the time, got %.3f", ratio)
+	}
+	if _, ok := WeightedChoice(items, []float64{1, 2}, r); ok {
+		t.Fatalf("expected false for mismatched lengths")
+	}
+	if _, ok := WeightedChoice(items, []float64{0, 0, 0}, r); ok {
+		t.Fatalf("expected false for all-zero weights")
+	}
+}
+
+func TestReduceBatched(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	add := func(a, b int) int { return a + b }
+	if got := ReduceBatched(data, 3, 0, add, add); got != 28 {
+		t.Fatalf("expected sum 28 got %d", got)
+	}
+	if got := ReduceBatched(data, 0, 0, add, add); got != 28 {
+		t.Fatalf("expected sum 28 for size 0 got %d", got)
+	}
+	if got := ReduceBatched([]int{}, 3, 0, add, add); got != 0 {
+		t.Fatalf("expected initial for empty slice got %d", got)
+	}
+}
+
+func TestIsSorted(t *testing.T) {
+	if !IsSorted([]int{1, 2, 2, 5}) {
+		t.Fatalf("expected sorted slice")
+	}
+	if IsSorted([]int{5, 3, 1}) {
+		t.Fatalf("reverse-sorted slice reported as sorted")
+	}
+	if !IsSorted([]int{42}) {
+		t.Fatalf("single element is trivially sorted")
+	}
+}
+
+func TestIsSortedBy(t *testing.T) {
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+	if !IsSortedBy([]string{"a", "bb", "cc", "ddd"}, byLen) {
+		t.Fatalf("expected sorted by length")
+	}
+	if IsSortedBy([]string{"ddd", "bb", "a"}, byLen) {
+		t.Fatalf("reverse-sorted slice reported as sorted")
+	}
+	if !IsSortedBy([]string{"x"}, byLen) {
+		t.Fatalf("single element is trivially sorted")
+	}
+}
+
+func TestBinarySearchBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{{1, "a"}, {4, "b"}, {9, "c"}, {12, "d"}}
+	byID := func(u user) int { return u.ID }
+	if i, ok := BinarySearchBy(users, 9, byID); !ok || i != 2 {
+		t.Fatalf("expected to find 9 at index 2, got %d %v", i, ok)
+	}
+	if i, ok := BinarySearchBy(users, 5, byID); ok || i != 2 {
+		t.Fatalf("expected insertion index 2 for 5, got %d %v", i, ok)
+	}
+	if i, ok := BinarySearchBy([]user{}, 5, byID); ok || i != 0 {
+		t.Fatalf("expected 0 false for empty slice, got %d %v", i, ok)
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	data := []int{2, 4, 6}
+	data = InsertSorted(data, 5)
+	if !reflect.DeepEqual(data, []int{2, 4, 5, 6}) {
+		t.Fatalf("insert middle mismatch: %v", data)
+	}
+	data = InsertSorted(data, 1)
+	if !reflect.DeepEqual(data, []int{1, 2, 4, 5, 6}) {
+		t.Fatalf("insert front mismatch: %v", data)
+	}
+	data = InsertSorted(data, 9)
+	if !reflect.DeepEqual(data, []int{1, 2, 4, 5, 6, 9}) {
+		t.Fatalf("insert end mismatch: %v", data)
+	}
+	if got := InsertSorted(nil, 3); !reflect.DeepEqual(got, []int{3}) {
+		t.Fatalf("insert into nil mismatch: %v", got)
+	}
+}
+
+func TestRotateCopy(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	if got := RotateLeftCopy(data, 2); !reflect.DeepEqual(got, []int{3, 4, 5, 1, 2}) {
+		t.Fatalf("rotate left mismatch: %v", got)
+	}
+	if got := RotateRightCopy(data, 2); !reflect.DeepEqual(got, []int{4, 5, 1, 2, 3}) {
+		t.Fatalf("rotate right mismatch: %v", got)
+	}
+	if got := RotateLeftCopy(data, 7); !reflect.DeepEqual(got, []int{3, 4, 5, 1, 2}) {
+		t.Fatalf("rotate left k > len mismatch: %v", got)
+	}
+	if got := RotateRightCopy(data, 12); !reflect.DeepEqual(got, []int{4, 5, 1, 2, 3}) {
+		t.Fatalf("rotate right k > len mismatch: %v", got)
+	}
+	if !reflect.DeepEqual(data, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("original slice modified")
+	}
+	if got := RotateLeftCopy([]int{}, 3); len(got) != 0 {
+		t.Fatalf("expected empty result for empty slice")
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	data := []string{"a", "b", "c"}
+	indices, values := Enumerate(data)
+	if len(indices) != len(values) || len(values) != len(data) {
+		t.Fatalf("length mismatch")
+	}
+	for i, idx := range indices {
+		if idx != i {
+			t.Fatalf("expected sequential indices, got %v", indices)
+		}
+	}
+	values[0] = "z"
+	if data[0] != "a" {
+		t.Fatalf("expected values to be a copy")
+	}
+}
+
+func TestJoinToString(t *testing.T) {
+	got := JoinToString([]int{1, 2, 3}, ", ", func(v int) string { return "#" + strconv.Itoa(v) })
+	if got != "#1, #2, #3" {
+		t.Fatalf("jointostring mismatch: %q", got)
+	}
+	if got := JoinToString([]int{}, ", ", strconv.Itoa); got != "" {
+		t.Fatalf("expected empty string, got %q", got)
+	}
+}
+
+func TestWindowStep(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+	got := WindowStep(data, 3, 1)
+	if !reflect.DeepEqual(got, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}}) {
+		t.Fatalf("step 1 mismatch: %v", got)
+	}
+	if !reflect.DeepEqual(got, Window(data, 3)) {
+		t.Fatalf("step 1 should equal Window")
+	}
+	if got := WindowStep(data, 2, 2); !reflect.DeepEqual(got, [][]int{{1, 2}, {3, 4}, {5, 6}}) {
+		t.Fatalf("step 2 mismatch: %v", got)
+	}
+	if got := WindowStep(data, 3, 2); !reflect.DeepEqual(got, [][]int{{1, 2, 3}, {3, 4, 5}}) {
+		t.Fatalf("step 2 overlapping mismatch: %v", got)
+	}
+	if WindowStep(data, 0, 1) != nil || WindowStep(data, 2, 0) != nil || WindowStep(data, 7, 1) != nil {
+		t.Fatalf("expected nil for invalid size or step")
+	}
+}
+
+func TestTryMap(t *testing.T) {
+	data := []string{"1", "x", "3", "y", "5"}
+	results, errs := TryMap(data, strconv.Atoi)
+	if !reflect.DeepEqual(results, []int{1, 0, 3, 0, 5}) {
+		t.Fatalf("trymap results mismatch: %v", results)
+	}
+	if len(errs) != len(data) {
+		t.Fatalf("expected parallel error slice, got %v", errs)
+	}
+	for i, err := range errs {
+		if failed := i == 1 || i == 3; failed != (err != nil) {
+			t.Fatalf("unexpected error state at %d: %v", i, err)
+		}
+	}
+	if _, errs := TryMap([]string{"1", "2"}, strconv.Atoi); errs != nil {
+		t.Fatalf("expected nil errors on success, got %v", errs)
+	}
+}
+
+func TestBatchBalanced(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	batches := BatchBalanced(data, 3)
+	if !reflect.DeepEqual(batches, [][]int{{1, 2, 3}, {4, 5}, {6, 7}}) {
+		t.Fatalf("batchbalanced mismatch: %v", batches)
+	}
+	batches = BatchBalanced([]int{1, 2}, 4)
+	if len(batches) != 4 || len(batches[0]) != 1 || len(batches[1]) != 1 || len(batches[2]) != 0 || len(batches[3]) != 0 {
+		t.Fatalf("expected trailing empty batches: %v", batches)
+	}
+	if BatchBalanced(data, 0) != nil {
+		t.Fatalf("expected nil for count 0")
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	type step struct {
+		Name string
+		Deps []string
+	}
+	key := func(s step) string { return s.Name }
+	deps := func(s step) []string { return s.Deps }
+	names := func(steps []step) []string { return Map(steps, key) }
+
+	// linear chain given in reverse
+	chain := []step{{"c", []string{"b"}}, {"b", []string{"a"}}, {"a", nil}}
+	sorted, err := TopoSort(chain, key, deps)
+	if err != nil || !reflect.DeepEqual(names(sorted), []string{"a", "b", "c"}) {
+		t.Fatalf("chain mismatch: %v %v", names(sorted), err)
+	}
+
+	// diamond: d depends on b and c, which both depend on a
+	diamond := []step{{"d", []string{"b", "c"}}, {"b", []string{"a"}}, {"c", []string{"a"}}, {"a", nil}}
+	sorted, err = TopoSort(diamond, key, deps)
+	if err != nil || !reflect.DeepEqual(names(sorted), []string{"a", "b", "c", "d"}) {
+		t.Fatalf("diamond mismatch: %v %v", names(sorted), err)
+	}
+
+	cycle := []step{{"a", []string{"c"}}, {"b", []string{"a"}}, {"c", []string{"b"}}}
+	if _, err := TopoSort(cycle, key, deps); err == nil {
+		t.Fatalf("expected error for cycle")
+	}
+	if _, err := TopoSort([]step{{"a", []string{"missing"}}}, key, deps); err == nil {
+		t.Fatalf("expected error for unknown dependency")
+	}
+}
+
+func TestSlidingReduce(t *testing.T) {
+	sums := SlidingReduce([]int{1, 2, 3, 4}, 2, 0, func(acc, v int) int { return acc + v })
+	if !reflect.DeepEqual(sums, []int{3, 5, 7}) {
+		t.Fatalf("slidingreduce mismatch: %v", sums)
+	}
+	if SlidingReduce([]int{1, 2}, 3, 0, func(acc, v int) int { return acc + v }) != nil {
+		t.Fatalf("expected nil for window larger than slice")
+	}
+}
+
+func TestSplitN(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	parts := SplitN(data, 3)
+	if !reflect.DeepEqual(Map(parts, func(p []int) int { return len(p) }), []int{4, 3, 3}) {
+		t.Fatalf("splitn sizes mismatch: %v", parts)
+	}
+	if got := SplitN(data[:2], 5); len(got) != 2 {
+		t.Fatalf("expected at most len parts, got %v", got)
+	}
+	if SplitN(data, 0) != nil {
+		t.Fatalf("expected nil for n=0")
+	}
+}
+
+func TestDefaultIfEmpty(t *testing.T) {
+	data := []string{"a", "b"}
+	if got := DefaultIfEmpty(data, "none"); &got[0] != &data[0] {
+		t.Fatalf("expected non-empty slice to be returned as-is")
+	}
+	if got := DefaultIfEmpty([]string{}, "none"); !reflect.DeepEqual(got, []string{"none"}) {
+		t.Fatalf("expected defaults, got %v", got)
+	}
+	if got := DefaultIfEmpty[string](nil); len(got) != 0 {
+		t.Fatalf("expected empty result without defaults, got %v", got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy([]string{"apple", "bob", "avocado", "cat", "banana"}, func(s string) byte { return s[0] })
+	expected := map[byte][]string{'a': {"apple", "avocado"}, 'b': {"bob", "banana"}, 'c': {"cat"}}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Fatalf("groupby mismatch: %v", groups)
+	}
+}
+
+func TestClone(t *testing.T) {
+	data := []int{1, 2, 3}
+	c := Clone(data)
+	c[0] = 100
+	if !reflect.DeepEqual(data, []int{1, 2, 3}) || !reflect.DeepEqual(c, []int{100, 2, 3}) {
+		t.Fatalf("clone not independent: %v %v", data, c)
+	}
+}
+
+func TestEachBatchWhile(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	var seen [][]int
+	EachBatchWhile(data, 2, func(batch []int) bool {
+		seen = append(seen, batch)
+		return !slices.Contains(batch, 4)
+	})
+	if !reflect.DeepEqual(seen, [][]int{{1, 2}, {3, 4}}) {
+		t.Fatalf("expected to stop after batch containing 4: %v", seen)
+	}
+	calls := 0
+	EachBatchWhile(data, 3, func([]int) bool { calls++; return true })
+	if calls != 3 {
+		t.Fatalf("expected 3 batches got %d", calls)
+	}
+}
+
+func TestChunkWhile(t *testing.T) {
+	chunks := ChunkWhile([]int{1, 2, 5, 6, 10}, func(prev, curr int) bool { return curr-prev <= 1 })
+	if !reflect.DeepEqual(chunks, [][]int{{1, 2}, {5, 6}, {10}}) {
+		t.Fatalf("chunkwhile mismatch: %v", chunks)
+	}
+	if ChunkWhile([]int{}, func(_, _ int) bool { return true }) != nil {
+		t.Fatalf("expected nil for empty input")
+	}
+}
+
+func TestUniqueWithDuplicates(t *testing.T) {
+	unique, dupes := UniqueWithDuplicates([]int{1, 2, 2, 3, 3, 3})
+	if !reflect.DeepEqual(unique, []int{1, 2, 3}) || !reflect.DeepEqual(dupes, []int{2, 3, 3}) {
+		t.Fatalf("uniquewithduplicates mismatch: %v %v", unique, dupes)
+	}
+	if _, dupes := UniqueWithDuplicates([]int{1, 2, 3}); dupes != nil {
+		t.Fatalf("expected no duplicates, got %v", dupes)
+	}
+}
+
+func TestSliceToPointers(t *testing.T) {
+	data := []int{1, 2, 3}
+	ptrs := SliceToPointers(data)
+	*ptrs[0] = 100
+	if data[0] != 1 {
+		t.Fatalf("expected pointers to copies, original modified")
+	}
+	if got := PointersToSlice(ptrs); !reflect.DeepEqual(got, []int{100, 2, 3}) {
+		t.Fatalf("round trip mismatch: %v", got)
+	}
+	two := 2
+	if got := PointersToSlice([]*int{nil, &two}); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Fatalf("nil pointer should become zero value: %v", got)
+	}
+}
+
+func TestBatchKeepGroups(t *testing.T) {
+	type row struct {
+		Order int
+		Item  string
+	}
+	rows := []row{{1, "a"}, {1, "b"}, {2, "c"}, {2, "d"}, {3, "e"}, {4, "f"}, {4, "g"}, {4, "h"}, {4, "i"}}
+	byOrder := func(r row) int { return r.Order }
+	batches := BatchKeepGroups(rows, 3, byOrder)
+	got := Map(batches, func(b []row) []int { return Map(b, byOrder) })
+	// order 2 would straddle the first boundary, order 4 is larger than maxSize
+	expected := [][]int{{1, 1}, {2, 2, 3}, {4, 4, 4, 4}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("batchkeepgroups mismatch: %v", got)
+	}
+	if BatchKeepGroups(rows, 0, byOrder) != nil {
+		t.Fatalf("expected nil for maxSize 0")
+	}
+}
+
+func TestMapWithState(t *testing.T) {
+	deltas, last := MapWithState([]int{3, 5, 4, 10}, 0, func(prev, v int) (int, int) { return v, v - prev })
+	if !reflect.DeepEqual(deltas, []int{3, 2, -1, 6}) || last != 10 {
+		t.Fatalf("mapwithstate mismatch: %v %d", deltas, last)
+	}
+}
+
+func TestFilterBatches(t *testing.T) {
+	batches := Batch([]int{1, 2, 3, 5, 7, 9, 10}, 3)
+	got := FilterBatches(batches, func(v int) bool { return v%2 == 0 })
+	// the middle batch {5, 7, 9} has no even numbers and is dropped
+	if !reflect.DeepEqual(got, [][]int{{2}, {10}}) {
+		t.Fatalf("filterbatches mismatch: %v", got)
+	}
+}
+
+func TestGet(t *testing.T) {
+	data := []string{"a", "b", "c"}
+	if v, ok := Get(data, 1); !ok || v != "b" {
+		t.Fatalf("expected b got %q %v", v, ok)
+	}
+	if v, ok := Get(data, -1); ok || v != "" {
+		t.Fatalf("expected not found for negative index")
+	}
+	if v, ok := Get(data, 3); ok || v != "" {
+		t.Fatalf("expected not found for oversized index")
+	}
+	if GetOr(data, 2, "z") != "c" || GetOr(data, -1, "z") != "z" || GetOr(data, 10, "z") != "z" {
+		t.Fatalf("getor mismatch")
+	}
+}
+
+func TestAt(t *testing.T) {
+	data := []int{10, 20, 30}
+	if v, ok := At(data, -1); !ok || v != 30 {
+		t.Fatalf("expected last element got %d %v", v, ok)
+	}
+	if v, ok := At(data, 0); !ok || v != 10 {
+		t.Fatalf("expected first element got %d %v", v, ok)
+	}
+	if v, ok := At(data, -3); !ok || v != 10 {
+		t.Fatalf("expected first element via -len got %d %v", v, ok)
+	}
+	if _, ok := At(data, -4); ok {
+		t.Fatalf("expected out of range for -4")
+	}
+	if _, ok := At(data, 3); ok {
+		t.Fatalf("expected out of range for 3")
+	}
+}
+
+func TestTruncateWithMore(t *testing.T) {
+	more := func(remaining int) string { return fmt.Sprintf("and %d more...", remaining) }
+	data := []string{"a", "b", "c", "d", "e", "f"}
+	if got := TruncateWithMore(data, 3, more); !reflect.DeepEqual(got, []string{"a", "b", "and 4 more..."}) {
+		t.Fatalf("truncation mismatch: %v", got)
+	}
+	if data[2] != "c" {
+		t.Fatalf("original slice modified")
+	}
+	if got := TruncateWithMore(data, 6, more); !reflect.DeepEqual(got, data) {
+		t.Fatalf("expected no truncation: %v", got)
+	}
+}
+
+func TestBatchIndices(t *testing.T) {
+	if got := BatchIndices(7, 3); !reflect.DeepEqual(got, [][2]int{{0, 3}, {3, 6}, {6, 7}}) {
+		t.Fatalf("batchindices mismatch: %v", got)
+	}
+	if BatchIndices(7, 0) != nil || BatchIndices(0, 3) != nil {
+		t.Fatalf("expected nil for size 0 or empty length")
+	}
+}
+
+func TestGroupReduce(t *testing.T) {
+	type payment struct {
+		User   string
+		Amount int
+	}
+	payments := []payment{{"a", 10}, {"b", 5}, {"a", 7}, {"c", 1}, {"b", 2}}
+	totals := GroupReduce(payments, func(p payment) string { return p.User }, 0, func(acc int, p payment) int { return acc + p.Amount })
+	if !reflect.DeepEqual(totals, map[string]int{"a": 17, "b": 7, "c": 1}) {
+		t.Fatalf("groupreduce mismatch: %v", totals)
+	}
+}
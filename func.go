@@ -0,0 +1,28 @@
+package fn
+
+// Curry2 converts a two-argument function into a chain of single-argument functions
+func Curry2[A, B, R any](f func(A, B) R) func(A) func(B) R {
+	return func(a A) func(B) R {
+		return func(b B) R { return f(a, b) }
+	}
+}
+
+// Uncurry2 converts a chain of single-argument functions back into a two-argument function
+func Uncurry2[A, B, R any](f func(A) func(B) R) func(A, B) R {
+	return func(a A, b B) R { return f(a)(b) }
+}
+
+// Partial binds the first argument of a two-argument function
+func Partial[A, B, R any](f func(A, B) R, a A) func(B) R {
+	return func(b B) R { return f(a, b) }
+}
+
+// Const returns a function that always returns v
+func Const[T any](v T) func() T {
+	return func() T { return v }
+}
+
+// Identity returns v unchanged
+func Identity[T any](v T) T {
+	return v
+}
@@ -0,0 +1,38 @@
+package fn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCurry2(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	addTen := Curry2(add)(10)
+	if got := Map([]int{1, 2, 3}, addTen); !reflect.DeepEqual(got, []int{11, 12, 13}) {
+		t.Fatalf("curried map mismatch: %v", got)
+	}
+	if got := Uncurry2(Curry2(add))(3, 4); got != 7 {
+		t.Fatalf("uncurry round trip mismatch: %d", got)
+	}
+}
+
+func TestPartial(t *testing.T) {
+	mul := func(a, b int) int { return a * b }
+	triple := Partial(mul, 3)
+	if got := Map([]int{1, 2, 3}, triple); !reflect.DeepEqual(got, []int{3, 6, 9}) {
+		t.Fatalf("partial map mismatch: %v", got)
+	}
+}
+
+func TestConstIdentity(t *testing.T) {
+	if Identity(42) != 42 || Identity("a") != "a" {
+		t.Fatalf("identity mismatch")
+	}
+	if got := Map([]int{1, 2}, Identity[int]); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("identity map mismatch: %v", got)
+	}
+	f := Const("x")
+	if f() != "x" || f() != "x" {
+		t.Fatalf("const should always return its value")
+	}
+}
@@ -0,0 +1,58 @@
+package fn
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// KeysSorted returns the keys of a map sorted in ascending order
+func KeysSorted[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// MergeMapsInto copies every entry of srcs into dst in order, so later maps override earlier ones.
+// dst must be non-nil; nil entries in srcs are skipped.
+func MergeMapsInto[K comparable, V any](dst map[K]V, srcs ...map[K]V) {
+	for _, src := range srcs {
+		maps.Copy(dst, src)
+	}
+}
+
+// CloneMap returns a shallow copy of the map; the values themselves are not copied
+func CloneMap[K comparable, V any](m map[K]V) map[K]V {
+	return maps.Clone(m)
+}
+
+// MapToSlice projects each entry of a map into a slice element.
+// The order of the result is unspecified, like map iteration; sort it if needed.
+func MapToSlice[K comparable, V any, R any](m map[K]V, f func(K, V) R) []R {
+	result := make([]R, 0, len(m))
+	for k, v := range m {
+		result = append(result, f(k, v))
+	}
+	return result
+}
+
+// ZipMap builds a map pairing keys[i] with values[i], truncating to the shorter slice.
+// Duplicate keys keep the last value.
+func ZipMap[K comparable, V any](keys []K, values []V) map[K]V {
+	n := min(len(keys), len(values))
+	result := make(map[K]V, n)
+	for i := range n {
+		result[keys[i]] = values[i]
+	}
+	return result
+}
+
+// DeleteMapFunc removes every entry of m that satisfies the predicate and returns how many were removed
+func DeleteMapFunc[K comparable, V any](m map[K]V, pred func(K, V) bool) int {
+	removed := 0
+	for k, v := range m {
+		if pred(k, v) {
+			delete(m, k)
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,69 @@
+package fn
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestKeysSorted(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "d": 4, "b": 2}
+	if got := KeysSorted(m); !reflect.DeepEqual(got, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("keys not sorted: %v", got)
+	}
+	if got := KeysSorted(map[int]bool{}); len(got) != 0 {
+		t.Fatalf("expected no keys for empty map")
+	}
+}
+
+func TestMergeMapsInto(t *testing.T) {
+	dst := map[string]int{"a": 1, "b": 1}
+	MergeMapsInto(dst, map[string]int{"b": 2, "c": 2}, nil, map[string]int{"c": 3})
+	expected := map[string]int{"a": 1, "b": 2, "c": 3}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Fatalf("merge mismatch: %v", dst)
+	}
+}
+
+func TestCloneMap(t *testing.T) {
+	m := map[string]int{"a": 1}
+	c := CloneMap(m)
+	c["a"] = 2
+	c["b"] = 3
+	if !reflect.DeepEqual(m, map[string]int{"a": 1}) {
+		t.Fatalf("original map modified: %v", m)
+	}
+}
+
+func TestMapToSlice(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	got := MapToSlice(m, func(k string, v int) string { return fmt.Sprintf("%s=%d", k, v) })
+	slices.Sort(got)
+	if !reflect.DeepEqual(got, []string{"a=1", "b=2", "c=3"}) {
+		t.Fatalf("maptoslice mismatch: %v", got)
+	}
+}
+
+func TestZipMap(t *testing.T) {
+	if got := ZipMap([]string{"a", "b"}, []int{1, 2}); !reflect.DeepEqual(got, map[string]int{"a": 1, "b": 2}) {
+		t.Fatalf("zipmap mismatch: %v", got)
+	}
+	if got := ZipMap([]string{"a", "b", "c"}, []int{1, 2}); !reflect.DeepEqual(got, map[string]int{"a": 1, "b": 2}) {
+		t.Fatalf("expected truncation to shorter slice: %v", got)
+	}
+	if got := ZipMap([]string{"a", "b", "a"}, []int{1, 2, 3}); !reflect.DeepEqual(got, map[string]int{"a": 3, "b": 2}) {
+		t.Fatalf("expected last value to win: %v", got)
+	}
+}
+
+func TestDeleteMapFunc(t *testing.T) {
+	scores := map[string]int{"a": 10, "b": 55, "c": 70, "d": 30}
+	removed := DeleteMapFunc(scores, func(_ string, v int) bool { return v < 50 })
+	if removed != 2 {
+		t.Fatalf("expected 2 removed got %d", removed)
+	}
+	if !reflect.DeepEqual(scores, map[string]int{"b": 55, "c": 70}) {
+		t.Fatalf("remaining entries mismatch: %v", scores)
+	}
+}
@@ -0,0 +1,183 @@
+package fn
+
+import (
+	"math"
+	"slices"
+)
+
+// Signed is a constraint that permits any signed integer type
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint that permits any unsigned integer type
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint that permits any integer type
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint that permits any floating-point type
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint that permits any integer or floating-point type
+type Number interface {
+	Integer | Float
+}
+
+// Abs returns the absolute value of v
+func Abs[T Number](v T) T {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Sign returns -1 if v is negative, 1 if v is positive and 0 otherwise
+func Sign[T Number](v T) T {
+	var one T = 1
+	switch {
+	case v < 0:
+		return -one
+	case v > 0:
+		return one
+	}
+	return 0
+}
+
+// GCD returns the greatest common divisor of a and b, with GCD(0, x) == |x|
+func GCD[T Integer](a, b T) T {
+	a, b = Abs(a), Abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// LCM returns the least common multiple of a and b, or 0 if either is 0
+func LCM[T Integer](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return Abs(a / GCD(a, b) * b)
+}
+
+// GCDAll returns the greatest common divisor of all values, or 0 for an empty slice
+func GCDAll[T Integer](slice []T) T {
+	return Reduce(slice, 0, GCD[T])
+}
+
+// LCMAll returns the least common multiple of all values, or 1 for an empty slice
+func LCMAll[T Integer](slice []T) T {
+	return Reduce(slice, 1, LCM[T])
+}
+
+// Mean returns the arithmetic mean of the slice, or 0 for an empty slice
+func Mean[T Number](slice []T) float64 {
+	if len(slice) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range slice {
+		sum += float64(v)
+	}
+	return sum / float64(len(slice))
+}
+
+// Median returns the middle value of a sorted copy of the slice, averaging the two
+// middle values for even lengths. Returns 0 for an empty slice.
+func Median[T Number](slice []T) float64 {
+	if len(slice) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(slice)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (float64(sorted[mid-1]) + float64(sorted[mid])) / 2
+	}
+	return float64(sorted[mid])
+}
+
+// Mode returns the most frequent element, preferring the earliest one on ties
+func Mode[T comparable](slice []T) (T, bool) {
+	if len(slice) == 0 {
+		var zero T
+		return zero, false
+	}
+	counts := make(map[T]int, len(slice))
+	for _, v := range slice {
+		counts[v]++
+	}
+	mode, best := slice[0], 0
+	for _, v := range slice {
+		if c := counts[v]; c > best {
+			mode, best = v, c
+		}
+	}
+	return mode, true
+}
+
+// Variance returns the population variance of the slice (dividing by N), or 0 for an empty slice
+func Variance[T Number](slice []T) float64 {
+	if len(slice) == 0 {
+		return 0
+	}
+	return sumSquaredDeviations(slice) / float64(len(slice))
+}
+
+// SampleVariance returns the sample variance of the slice (dividing by N-1), or 0 for fewer than two elements
+func SampleVariance[T Number](slice []T) float64 {
+	if len(slice) < 2 {
+		return 0
+	}
+	return sumSquaredDeviations(slice) / float64(len(slice)-1)
+}
+
+// StdDev returns the population standard deviation of the slice, the square root of Variance
+func StdDev[T Number](slice []T) float64 {
+	return math.Sqrt(Variance(slice))
+}
+
+func sumSquaredDeviations[T Number](slice []T) float64 {
+	mean := Mean(slice)
+	var sum float64
+	for _, v := range slice {
+		d := float64(v) - mean
+		sum += d * d
+	}
+	return sum
+}
+
+// Percentile returns the p-th percentile of the slice using the nearest-rank method.
+// p is clamped to [0, 100] and the input slice is not modified. Returns 0 for an empty slice.
+func Percentile[T Number](slice []T, p float64) T {
+	if len(slice) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(slice)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(Clamp(p, 0, 100) / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// Histogram counts the values falling into each bucket defined by the sorted boundaries.
+// The result has len(boundaries)+1 buckets: bucket 0 counts values below boundaries[0],
+// bucket i covers [boundaries[i-1], boundaries[i]), and the last bucket counts values at or
+// above the final boundary.
+func Histogram[T Number](slice []T, boundaries []T) []int {
+	counts := make([]int, len(boundaries)+1)
+	for _, v := range slice {
+		i := slices.IndexFunc(boundaries, func(b T) bool { return b > v })
+		if i < 0 {
+			i = len(boundaries)
+		}
+		counts[i]++
+	}
+	return counts
+}
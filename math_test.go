@@ -0,0 +1,142 @@
+package fn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAbs(t *testing.T) {
+	if Abs(-5) != 5 || Abs(0) != 0 || Abs(5) != 5 {
+		t.Fatalf("int abs mismatch")
+	}
+	if Abs(-2.5) != 2.5 || Abs(0.0) != 0 || Abs(2.5) != 2.5 {
+		t.Fatalf("float abs mismatch")
+	}
+	if Abs(uint(3)) != 3 {
+		t.Fatalf("unsigned abs mismatch")
+	}
+}
+
+func TestSign(t *testing.T) {
+	if Sign(-7) != -1 || Sign(0) != 0 || Sign(7) != 1 {
+		t.Fatalf("int sign mismatch")
+	}
+	if Sign(-0.1) != -1 || Sign(0.0) != 0 || Sign(3.2) != 1 {
+		t.Fatalf("float sign mismatch")
+	}
+	if Sign(int8(-100)) != -1 {
+		t.Fatalf("int8 sign mismatch")
+	}
+}
+
+func TestGCDLCM(t *testing.T) {
+	if GCD(12, 18) != 6 || GCD(17, 5) != 1 {
+		t.Fatalf("gcd mismatch")
+	}
+	if GCD(0, 9) != 9 || GCD(9, 0) != 9 || GCD(-4, 6) != 2 {
+		t.Fatalf("gcd zero/negative handling mismatch")
+	}
+	if LCM(4, 6) != 12 || LCM(3, 5) != 15 {
+		t.Fatalf("lcm mismatch")
+	}
+	if LCM(0, 5) != 0 {
+		t.Fatalf("lcm with zero should be 0")
+	}
+	if got := GCDAll([]int{24, 36, 60}); got != 12 {
+		t.Fatalf("gcdall mismatch: %d", got)
+	}
+	if got := LCMAll([]uint{2, 3, 4}); got != 12 {
+		t.Fatalf("lcmall mismatch: %d", got)
+	}
+	if GCDAll([]int{}) != 0 || LCMAll([]int{}) != 1 {
+		t.Fatalf("empty slice identities mismatch")
+	}
+}
+
+func TestMean(t *testing.T) {
+	if got := Mean([]int{1, 2, 3, 4}); got != 2.5 {
+		t.Fatalf("expected mean 2.5 got %v", got)
+	}
+	if Mean([]float64{}) != 0 {
+		t.Fatalf("expected 0 for empty slice")
+	}
+}
+
+func TestMedian(t *testing.T) {
+	data := []int{5, 1, 3}
+	if got := Median(data); got != 3 {
+		t.Fatalf("odd median mismatch: %v", got)
+	}
+	if !reflect.DeepEqual(data, []int{5, 1, 3}) {
+		t.Fatalf("original slice modified")
+	}
+	if got := Median([]int{4, 1, 3, 2}); got != 2.5 {
+		t.Fatalf("even median mismatch: %v", got)
+	}
+	if Median([]int{}) != 0 {
+		t.Fatalf("expected 0 for empty slice")
+	}
+}
+
+func TestMode(t *testing.T) {
+	if v, ok := Mode([]string{"a", "b", "b", "c", "b", "a"}); !ok || v != "b" {
+		t.Fatalf("mode mismatch: %v %v", v, ok)
+	}
+	if v, ok := Mode([]int{3, 1, 1, 3}); !ok || v != 3 {
+		t.Fatalf("expected earliest value on tie, got %v", v)
+	}
+	if v, ok := Mode([]int{}); ok || v != 0 {
+		t.Fatalf("expected no mode for empty slice")
+	}
+}
+
+func TestVarianceStdDev(t *testing.T) {
+	data := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	// mean 5, squared deviations sum to 32
+	if got := Variance(data); got != 4 {
+		t.Fatalf("expected variance 4 got %v", got)
+	}
+	if got := StdDev(data); got != 2 {
+		t.Fatalf("expected stddev 2 got %v", got)
+	}
+	if got := SampleVariance(data); got != 32.0/7 {
+		t.Fatalf("expected sample variance 32/7 got %v", got)
+	}
+	if Variance([]int{}) != 0 || StdDev([]int{}) != 0 || SampleVariance([]int{1}) != 0 {
+		t.Fatalf("expected 0 for empty or short slices")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	data := []int{15, 20, 35, 40, 50}
+	if got := Percentile(data, 50); got != 35 {
+		t.Fatalf("expected p50 35 got %d", got)
+	}
+	if got := Percentile(data, 0); got != 15 {
+		t.Fatalf("expected p0 to be min got %d", got)
+	}
+	if got := Percentile(data, 100); got != 50 {
+		t.Fatalf("expected p100 to be max got %d", got)
+	}
+	if Percentile(data, -10) != 15 || Percentile(data, 250) != 50 {
+		t.Fatalf("expected p to be clamped")
+	}
+	if got := Percentile([]int{50, 15, 40, 20, 35}, 30); got != 20 {
+		t.Fatalf("expected p30 20 got %d", got)
+	}
+	if !reflect.DeepEqual(data, []int{15, 20, 35, 40, 50}) {
+		t.Fatalf("original slice modified")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	latencies := []float64{1, 5, 9.9, 10, 25, 49, 50, 120, -3}
+	counts := Histogram(latencies, []float64{0, 10, 50, 100})
+	// buckets: <0, [0,10), [10,50), [50,100), >=100
+	if !reflect.DeepEqual(counts, []int{1, 3, 3, 1, 1}) {
+		t.Fatalf("histogram mismatch: %v", counts)
+	}
+	if got := Histogram([]int{1, 2, 3}, nil); !reflect.DeepEqual(got, []int{3}) {
+		t.Fatalf("expected single bucket without boundaries: %v", got)
+	}
+}
@@ -0,0 +1,84 @@
+package fn
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MemoizeLRU wraps f with a cache holding at most capacity results, evicting the least recently
+// used entry when full. It is safe for concurrent use; f runs outside the lock, so concurrent
+// misses for the same key may each call f. A capacity <= 0 disables caching.
+func MemoizeLRU[K comparable, V any](capacity int, f func(K) V) func(K) V {
+	type entry struct {
+		key K
+		val V
+	}
+	var (
+		mu    sync.Mutex
+		order = list.New() // front is most recently used
+		items = make(map[K]*list.Element)
+	)
+	return func(k K) V {
+		mu.Lock()
+		if el, ok := items[k]; ok {
+			order.MoveToFront(el)
+			v := el.Value.(*entry).val
+			mu.Unlock()
+			return v
+		}
+		mu.Unlock()
+
+		v := f(k)
+		if capacity <= 0 {
+			return v
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if el, ok := items[k]; ok {
+			el.Value.(*entry).val = v
+			order.MoveToFront(el)
+			return v
+		}
+		items[k] = order.PushFront(&entry{key: k, val: v})
+		if order.Len() > capacity {
+			oldest := order.Back()
+			order.Remove(oldest)
+			delete(items, oldest.Value.(*entry).key)
+		}
+		return v
+	}
+}
+
+// MemoizeTTL wraps f with a cache whose entries expire ttl after they were computed.
+// Expired entries are recomputed lazily on access; there is no background cleanup.
+// It is safe for concurrent use.
+func MemoizeTTL[K comparable, V any](ttl time.Duration, f func(K) V) func(K) V {
+	return memoizeTTL(ttl, f, time.Now)
+}
+
+func memoizeTTL[K comparable, V any](ttl time.Duration, f func(K) V, now func() time.Time) func(K) V {
+	type entry struct {
+		val     V
+		expires time.Time
+	}
+	var (
+		mu    sync.Mutex
+		cache = make(map[K]entry)
+	)
+	return func(k K) V {
+		mu.Lock()
+		if e, ok := cache[k]; ok && now().Before(e.expires) {
+			mu.Unlock()
+			return e.val
+		}
+		mu.Unlock()
+
+		v := f(k)
+		mu.Lock()
+		cache[k] = entry{val: v, expires: now().Add(ttl)}
+		mu.Unlock()
+		return v
+	}
+}
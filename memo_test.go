@@ -0,0 +1,65 @@
+package fn
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMemoizeLRU(t *testing.T) {
+	var calls []int
+	square := MemoizeLRU(2, func(k int) int {
+		calls = append(calls, k)
+		return k * k
+	})
+
+	square(1)
+	square(2)
+	if square(1) != 1 { // hit, 1 becomes most recently used
+		t.Fatalf("unexpected cached value")
+	}
+	square(3) // evicts 2, the least recently used
+	square(1) // still cached
+	square(2) // recomputed
+	if !reflect.DeepEqual(calls, []int{1, 2, 3, 2}) {
+		t.Fatalf("unexpected computations: %v", calls)
+	}
+	square(3) // evicted by the recomputed 2
+	if !reflect.DeepEqual(calls, []int{1, 2, 3, 2, 3}) {
+		t.Fatalf("unexpected computations after eviction: %v", calls)
+	}
+}
+
+func TestMemoizeLRUDisabled(t *testing.T) {
+	calls := 0
+	f := MemoizeLRU(0, func(k string) int { calls++; return len(k) })
+	f("a")
+	f("a")
+	if calls != 2 {
+		t.Fatalf("expected no caching with capacity 0, got %d calls", calls)
+	}
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	clock := time.Unix(0, 0)
+	calls := 0
+	f := memoizeTTL(time.Minute, func(k string) int {
+		calls++
+		return calls
+	}, func() time.Time { return clock })
+
+	if f("a") != 1 || f("a") != 1 {
+		t.Fatalf("expected cached value before expiry")
+	}
+	clock = clock.Add(59 * time.Second)
+	if f("a") != 1 {
+		t.Fatalf("expected entry to survive until ttl")
+	}
+	clock = clock.Add(time.Second)
+	if f("a") != 2 {
+		t.Fatalf("expected recomputation after expiry")
+	}
+	if f("b") != 3 || calls != 3 {
+		t.Fatalf("expected separate entry per key")
+	}
+}
@@ -0,0 +1,48 @@
+package fn
+
+// Pipeline wraps a slice with chainable operations, finished by Collect.
+// Every step returns a new Pipeline and never modifies the one it was called on,
+// so a pipeline can be branched and slices returned by Collect stay stable.
+// Because methods cannot introduce type parameters, Map is same-type only;
+// use the free Map function to change the element type.
+type Pipeline[T any] struct {
+	items []T
+}
+
+// NewPipeline starts a pipeline over slice. The input is never modified.
+func NewPipeline[T any](slice []T) Pipeline[T] {
+	return Pipeline[T]{items: slice}
+}
+
+// Filter keeps only the elements that satisfy the predicate
+func (p Pipeline[T]) Filter(pred func(T) bool) Pipeline[T] {
+	return Pipeline[T]{items: Filter(p.items, pred)}
+}
+
+// Map replaces each element with the result of f
+func (p Pipeline[T]) Map(f func(T) T) Pipeline[T] {
+	return Pipeline[T]{items: Map(p.items, f)}
+}
+
+// Limit keeps at most the first n elements
+func (p Pipeline[T]) Limit(n int) Pipeline[T] {
+	return Pipeline[T]{items: Limit(p.items, max(n, 0))}
+}
+
+// Reverse reverses the order of the elements
+func (p Pipeline[T]) Reverse() Pipeline[T] {
+	items := Clone(p.items)
+	Reverse(items)
+	return Pipeline[T]{items: items}
+}
+
+// Collect returns the resulting elements as a new slice owned by the caller
+func (p Pipeline[T]) Collect() []T {
+	return Clone(p.items)
+}
+
+// UniquePipeline removes duplicate elements from p, keeping the first occurrence.
+// It is a function rather than a method because it requires T to be comparable.
+func UniquePipeline[T comparable](p Pipeline[T]) Pipeline[T] {
+	return Pipeline[T]{items: Unique(Clone(p.items))}
+}
@@ -0,0 +1,58 @@
+package fn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	data := []int{5, 1, 4, 1, 3, 4, 2, 6}
+	even := func(v int) bool { return v%2 == 0 }
+	double := func(v int) int { return v * 2 }
+
+	got := UniquePipeline(NewPipeline(data)).Filter(even).Map(double).Reverse().Limit(2).Collect()
+
+	expected := Unique(append([]int(nil), data...))
+	expected = Map(Filter(expected, even), double)
+	Reverse(expected)
+	expected = Limit(expected, 2)
+
+	if !reflect.DeepEqual(got, expected) || !reflect.DeepEqual(got, []int{12, 4}) {
+		t.Fatalf("pipeline mismatch: %v != %v", got, expected)
+	}
+	if !reflect.DeepEqual(data, []int{5, 1, 4, 1, 3, 4, 2, 6}) {
+		t.Fatalf("original slice modified")
+	}
+}
+
+func TestPipelineEmpty(t *testing.T) {
+	if got := NewPipeline([]string{}).Filter(func(string) bool { return true }).Limit(-1).Collect(); len(got) != 0 {
+		t.Fatalf("expected empty result, got %v", got)
+	}
+}
+
+func TestPipelineStepsDoNotMutate(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	p := NewPipeline([]int{1, 2, 3, 4})
+	all := p.Collect()
+	p.Filter(even)
+	p.Map(func(v int) int { return v * 10 })
+	p.Reverse()
+	if !reflect.DeepEqual(all, []int{1, 2, 3, 4}) {
+		t.Fatalf("collected slice changed by later steps: %v", all)
+	}
+
+	// branching from a shared pipeline leaves it intact
+	q := p.Filter(even)
+	a := q.Map(func(v int) int { return v + 1 }).Collect()
+	b := q.Reverse().Collect()
+	if !reflect.DeepEqual(q.Collect(), []int{2, 4}) || !reflect.DeepEqual(a, []int{3, 5}) || !reflect.DeepEqual(b, []int{4, 2}) {
+		t.Fatalf("branches interfered: q=%v a=%v b=%v", q.Collect(), a, b)
+	}
+
+	// mutating a collected slice does not leak back into the pipeline
+	all[0] = 100
+	if got := p.Collect(); got[0] != 1 {
+		t.Fatalf("collect should return a copy, got %v", got)
+	}
+}
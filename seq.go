@@ -0,0 +1,181 @@
+package fn
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+)
+
+// ReservoirSample returns up to k elements chosen uniformly at random from seq using Algorithm R,
+// consuming the sequence exactly once. Returns nil if k <= 0.
+func ReservoirSample[T any](seq iter.Seq[T], k int, r *rand.Rand) []T {
+	if k <= 0 {
+		return nil
+	}
+	reservoir := make([]T, 0, k)
+	n := 0
+	for v := range seq {
+		if n < k {
+			reservoir = append(reservoir, v)
+		} else if j := r.Intn(n + 1); j < k {
+			reservoir[j] = v
+		}
+		n++
+	}
+	return reservoir
+}
+
+// GroupBySeq groups the elements of seq by the key returned by keyFn, preserving order within each group
+func GroupBySeq[T any, K comparable](seq iter.Seq[T], keyFn func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for v := range seq {
+		k := keyFn(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// ReduceSeq folds the elements of seq into a single value, starting from initial
+func ReduceSeq[T, R any](seq iter.Seq[T], initial R, f func(R, T) R) R {
+	result := initial
+	for v := range seq {
+		result = f(result, v)
+	}
+	return result
+}
+
+// AnySeq returns true if any element of seq satisfies the predicate, stopping at the first match
+func AnySeq[T any](seq iter.Seq[T], pred func(T) bool) bool {
+	for v := range seq {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllSeq returns true if every element of seq satisfies the predicate, stopping at the first failure
+func AllSeq[T any](seq iter.Seq[T], pred func(T) bool) bool {
+	for v := range seq {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// CountSeq consumes seq and returns the number of elements it produced
+func CountSeq[T any](seq iter.Seq[T]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+	return n
+}
+
+// TakeSeq yields at most the first n elements of seq, stopping the upstream producer afterwards
+func TakeSeq[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			if taken++; taken == n {
+				return
+			}
+		}
+	}
+}
+
+// DropSeq yields the elements of seq after skipping the first n
+func DropSeq[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		skipped := 0
+		for v := range seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ChunkSeq yields batches of up to size elements as seq produces them, ending with a shorter batch
+// if elements remain. Each batch is a fresh slice. Yields nothing if size <= 0.
+func ChunkSeq[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size <= 0 {
+			return
+		}
+		batch := make([]T, 0, size)
+		for v := range seq {
+			batch = append(batch, v)
+			if len(batch) == size {
+				if !yield(batch) {
+					return
+				}
+				batch = make([]T, 0, size)
+			}
+		}
+		if len(batch) > 0 {
+			yield(batch)
+		}
+	}
+}
+
+// FilterSeq yields only the elements of seq that satisfy the predicate
+func FilterSeq[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if pred(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// PipeSeq applies each transform to seq in order, left to right
+func PipeSeq[T any](seq iter.Seq[T], transforms ...func(iter.Seq[T]) iter.Seq[T]) iter.Seq[T] {
+	for _, transform := range transforms {
+		seq = transform(seq)
+	}
+	return seq
+}
+
+// ReverseSeq yields the elements of a finite seq in reverse order.
+// It materializes the whole sequence internally before yielding anything.
+func ReverseSeq[T any](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		buf := slices.Collect(seq)
+		for i := len(buf) - 1; i >= 0; i-- {
+			if !yield(buf[i]) {
+				return
+			}
+		}
+	}
+}
+
+// DedupSeq yields the elements of seq, suppressing values equal to the one immediately before them.
+// Non-adjacent repeats are kept.
+func DedupSeq[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev T
+		first := true
+		for v := range seq {
+			if !first && v == prev {
+				continue
+			}
+			first, prev = false, v
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
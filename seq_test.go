@@ -0,0 +1,181 @@
+package fn
+
+import (
+	"iter"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestReservoirSample(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	r := rand.New(rand.NewSource(1))
+	got := ReservoirSample(slices.Values(data), 4, r)
+	if len(got) != 4 {
+		t.Fatalf("expected 4 samples got %d", len(got))
+	}
+	for _, v := range got {
+		if !slices.Contains(data, v) {
+			t.Fatalf("sample %d not in source", v)
+		}
+	}
+	if len(Unique(slices.Clone(got))) != 4 {
+		t.Fatalf("expected distinct samples: %v", got)
+	}
+	if got := ReservoirSample(slices.Values(data[:2]), 5, r); len(got) != 2 {
+		t.Fatalf("expected whole stream when shorter than k, got %v", got)
+	}
+}
+
+func TestGroupBySeq(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	mod3 := func(v int) int { return v % 3 }
+	got := GroupBySeq(slices.Values(data), mod3)
+	if !reflect.DeepEqual(got, GroupBy(data, mod3)) {
+		t.Fatalf("groupbyseq mismatch: %v", got)
+	}
+	if !reflect.DeepEqual(got[1], []int{1, 4, 7}) {
+		t.Fatalf("unexpected group: %v", got[1])
+	}
+}
+
+func TestReduceSeq(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	add := func(acc, v int) int { return acc + v }
+	if got := ReduceSeq(slices.Values(data), 0, add); got != Reduce(data, 0, add) || got != 15 {
+		t.Fatalf("reduceseq mismatch: %d", got)
+	}
+}
+
+// countingSeq yields 1..n and records how many values were produced
+func countingSeq(n int, produced *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 1; i <= n; i++ {
+			*produced++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestAnySeq(t *testing.T) {
+	produced := 0
+	if !AnySeq(countingSeq(100, &produced), func(v int) bool { return v == 3 }) {
+		t.Fatalf("expected a match")
+	}
+	if produced != 3 {
+		t.Fatalf("expected producer to stop after 3 values, produced %d", produced)
+	}
+	if AnySeq(slices.Values([]int{1, 3, 5}), func(v int) bool { return v%2 == 0 }) {
+		t.Fatalf("expected no match")
+	}
+}
+
+func TestAllSeq(t *testing.T) {
+	produced := 0
+	if AllSeq(countingSeq(100, &produced), func(v int) bool { return v < 5 }) {
+		t.Fatalf("expected failure")
+	}
+	if produced != 5 {
+		t.Fatalf("expected producer to stop after 5 values, produced %d", produced)
+	}
+	if !AllSeq(slices.Values([]int{2, 4}), func(v int) bool { return v%2 == 0 }) {
+		t.Fatalf("expected all even")
+	}
+}
+
+func TestCountSeq(t *testing.T) {
+	if got := CountSeq(slices.Values([]string{"a", "b", "c"})); got != 3 {
+		t.Fatalf("expected 3 got %d", got)
+	}
+	if got := CountSeq(slices.Values([]string{})); got != 0 {
+		t.Fatalf("expected 0 got %d", got)
+	}
+}
+
+func TestTakeSeq(t *testing.T) {
+	produced := 0
+	got := slices.Collect(TakeSeq(countingSeq(100, &produced), 3))
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("takeseq mismatch: %v", got)
+	}
+	if produced != 3 {
+		t.Fatalf("expected producer to stop after 3 values, produced %d", produced)
+	}
+	if got := slices.Collect(TakeSeq(slices.Values([]int{1, 2}), 5)); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("takeseq beyond len mismatch: %v", got)
+	}
+	produced = 0
+	if got := slices.Collect(TakeSeq(countingSeq(100, &produced), 0)); len(got) != 0 || produced != 0 {
+		t.Fatalf("expected nothing consumed for n=0")
+	}
+}
+
+func TestDropSeq(t *testing.T) {
+	if got := slices.Collect(DropSeq(slices.Values([]int{1, 2, 3, 4}), 2)); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Fatalf("dropseq mismatch: %v", got)
+	}
+	if got := slices.Collect(DropSeq(slices.Values([]int{1, 2}), 5)); len(got) != 0 {
+		t.Fatalf("expected empty result: %v", got)
+	}
+}
+
+func TestChunkSeq(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	got := slices.Collect(ChunkSeq(slices.Values(data), 3))
+	if !reflect.DeepEqual(got, Batch(data, 3)) {
+		t.Fatalf("chunkseq mismatch: %v", got)
+	}
+	if got := slices.Collect(ChunkSeq(slices.Values(data), 0)); got != nil {
+		t.Fatalf("expected nothing for size 0, got %v", got)
+	}
+}
+
+func TestFilterSeq(t *testing.T) {
+	got := slices.Collect(FilterSeq(slices.Values([]int{1, 2, 3, 4}), func(v int) bool { return v%2 == 0 }))
+	if !reflect.DeepEqual(got, []int{2, 4}) {
+		t.Fatalf("filterseq mismatch: %v", got)
+	}
+}
+
+func TestPipeSeq(t *testing.T) {
+	produced := 0
+	seq := PipeSeq(countingSeq(100, &produced),
+		func(s iter.Seq[int]) iter.Seq[int] { return FilterSeq(s, func(v int) bool { return v%3 == 0 }) },
+		func(s iter.Seq[int]) iter.Seq[int] { return TakeSeq(s, 3) },
+	)
+	if got := slices.Collect(seq); !reflect.DeepEqual(got, []int{3, 6, 9}) {
+		t.Fatalf("pipeseq mismatch: %v", got)
+	}
+	if produced != 9 {
+		t.Fatalf("expected producer to stop after 9 values, produced %d", produced)
+	}
+	if got := slices.Collect(PipeSeq(slices.Values([]int{1, 2}))); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("expected identity without transforms: %v", got)
+	}
+}
+
+func TestReverseSeq(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	expected := Clone(data)
+	Reverse(expected)
+	if got := slices.Collect(ReverseSeq(slices.Values(data))); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("reverseseq mismatch: %v", got)
+	}
+	if got := slices.Collect(TakeSeq(ReverseSeq(slices.Values(data)), 2)); !reflect.DeepEqual(got, []int{4, 3}) {
+		t.Fatalf("expected early stop to work: %v", got)
+	}
+}
+
+func TestDedupSeq(t *testing.T) {
+	data := []string{"a", "a", "b", "b", "b", "a", "c", "c"}
+	if got := slices.Collect(DedupSeq(slices.Values(data))); !reflect.DeepEqual(got, []string{"a", "b", "a", "c"}) {
+		t.Fatalf("dedupseq mismatch: %v", got)
+	}
+	// a leading zero value must not be mistaken for a previous element
+	if got := slices.Collect(DedupSeq(slices.Values([]int{0, 0, 1}))); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Fatalf("dedupseq zero value mismatch: %v", got)
+	}
+}
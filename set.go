@@ -0,0 +1,72 @@
+package fn
+
+// ToSet builds a set from the elements of a slice, collapsing duplicates
+func ToSet[T comparable](slice []T) map[T]struct{} {
+	set := make(map[T]struct{}, len(slice))
+	for _, v := range slice {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// SetToSlice returns the members of a set as a slice in unspecified order
+func SetToSlice[T comparable](set map[T]struct{}) []T {
+	result := make([]T, 0, len(set))
+	for v := range set {
+		result = append(result, v)
+	}
+	return result
+}
+
+// SymmetricDifference returns the elements present in exactly one of a and b, without duplicates,
+// ordered by first appearance in a and then in b
+func SymmetricDifference[T comparable](a, b []T) []T {
+	inA, inB := ToSet(a), ToSet(b)
+	seen := make(map[T]struct{}, len(a)+len(b))
+	var result []T
+	collect := func(slice []T, other map[T]struct{}) {
+		for _, v := range slice {
+			if _, ok := other[v]; ok {
+				continue
+			}
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
+	}
+	collect(a, inB)
+	collect(b, inA)
+	return result
+}
+
+// Diff compares two slices as sets, returning the values only in new (added), only in old (removed),
+// and in both (common), without duplicates. added follows the order of new; removed and common
+// follow the order of old.
+func Diff[T comparable](old, new []T) (added, removed, common []T) {
+	inOld, inNew := ToSet(old), ToSet(new)
+	seen := make(map[T]struct{}, len(old)+len(new))
+	for _, v := range old {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		if _, ok := inNew[v]; ok {
+			common = append(common, v)
+		} else {
+			removed = append(removed, v)
+		}
+	}
+	for _, v := range new {
+		if _, ok := inOld[v]; ok {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		added = append(added, v)
+	}
+	return added, removed, common
+}
@@ -0,0 +1,55 @@
+package fn
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestToSet(t *testing.T) {
+	set := ToSet([]string{"a", "b", "a", "c", "b"})
+	if len(set) != 3 {
+		t.Fatalf("expected duplicates to collapse, got %v", set)
+	}
+	for _, v := range []string{"a", "b", "c"} {
+		if _, ok := set[v]; !ok {
+			t.Fatalf("missing member %q", v)
+		}
+	}
+}
+
+func TestSetToSlice(t *testing.T) {
+	data := []int{3, 1, 2}
+	got := SetToSlice(ToSet(data))
+	slices.Sort(got)
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("round trip mismatch: %v", got)
+	}
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	got := SymmetricDifference([]int{1, 2, 2, 3, 4}, []int{3, 4, 5, 5, 6})
+	if !reflect.DeepEqual(got, []int{1, 2, 5, 6}) {
+		t.Fatalf("overlapping mismatch: %v", got)
+	}
+	got = SymmetricDifference([]int{1, 2}, []int{3, 4})
+	if !reflect.DeepEqual(got, []int{1, 2, 3, 4}) {
+		t.Fatalf("disjoint inputs should return the union: %v", got)
+	}
+	if got := SymmetricDifference([]int{1, 2}, []int{2, 1}); len(got) != 0 {
+		t.Fatalf("expected empty result for equal sets: %v", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	added, removed, common := Diff([]string{"a", "b", "c", "b"}, []string{"c", "d", "a", "e", "d"})
+	if !reflect.DeepEqual(added, []string{"d", "e"}) {
+		t.Fatalf("added mismatch: %v", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"b"}) {
+		t.Fatalf("removed mismatch: %v", removed)
+	}
+	if !reflect.DeepEqual(common, []string{"a", "c"}) {
+		t.Fatalf("common mismatch: %v", common)
+	}
+}